@@ -0,0 +1,166 @@
+package analysis
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/adam-szerdahelyi/go-exoclick/exoclick"
+)
+
+type Period uint8
+
+const (
+	Daily Period = iota
+	Weekly
+	Monthly
+)
+
+func (p Period) Truncate(t time.Time) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+
+	switch p {
+	case Weekly:
+		offset := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -offset)
+	case Monthly:
+		return day.AddDate(0, 0, 1-day.Day())
+	default:
+		return day
+	}
+}
+
+func Sum(rows []*exoclick.Statistic) *exoclick.Statistic {
+	total := new(exoclick.Statistic)
+
+	var cost float64
+	for _, row := range rows {
+		add(total, row)
+		cost += float64(row.Cost)
+	}
+
+	total.Cost = float32(cost)
+
+	return total
+}
+
+func GroupBy(rows []*exoclick.Statistic, fields ...exoclick.StatisticsField) ([]*exoclick.Statistic, error) {
+	return group(rows, fields, func(s *exoclick.Statistic) *exoclick.Statistic { return s })
+}
+
+func Rollup(rows []*exoclick.Statistic, period Period, fields ...exoclick.StatisticsField) ([]*exoclick.Statistic, error) {
+	fields = append([]exoclick.StatisticsField{exoclick.Date}, fields...)
+
+	return group(rows, fields, func(s *exoclick.Statistic) *exoclick.Statistic {
+		if s.Date == nil {
+			return s
+		}
+
+		bucket := *s
+		date := period.Truncate(*s.Date)
+		bucket.Date = &date
+
+		return &bucket
+	})
+}
+
+func Rebucket(rows []*exoclick.Statistic, loc *time.Location, fields ...exoclick.StatisticsField) ([]*exoclick.Statistic, error) {
+	if loc == nil {
+		return nil, errors.New("location must be set")
+	}
+
+	for _, row := range rows {
+		if row.Date == nil || row.Hour == nil {
+			return nil, errors.New("cannot rebucket rows without date and hour")
+		}
+	}
+
+	fields = append([]exoclick.StatisticsField{exoclick.Date, exoclick.Hour}, fields...)
+
+	return group(rows, fields, func(s *exoclick.Statistic) *exoclick.Statistic {
+		ts, _ := s.Timestamp()
+		ts = ts.In(loc)
+
+		bucket := *s
+		date := time.Date(ts.Year(), ts.Month(), ts.Day(), 0, 0, 0, 0, loc)
+		hour := ts.Hour()
+		bucket.Date = &date
+		bucket.Hour = &hour
+
+		return &bucket
+	})
+}
+
+func group(rows []*exoclick.Statistic, fields []exoclick.StatisticsField, transform func(*exoclick.Statistic) *exoclick.Statistic) ([]*exoclick.Statistic, error) {
+	for _, field := range fields {
+		if _, ok := new(exoclick.Statistic).Dimension(field); !ok {
+			return nil, fmt.Errorf("cannot group by non-dimension field: \"%s\"", field)
+		}
+	}
+
+	index := make(map[string]*exoclick.Statistic)
+	costs := make(map[*exoclick.Statistic]float64)
+
+	var groups []*exoclick.Statistic
+
+	for _, row := range rows {
+		row = transform(row)
+		k := Key(row, fields...)
+
+		g, ok := index[k]
+		if !ok {
+			g = new(exoclick.Statistic)
+			for _, field := range fields {
+				copyDimension(g, row, field)
+			}
+
+			index[k] = g
+			groups = append(groups, g)
+		}
+
+		add(g, row)
+		costs[g] += float64(row.Cost)
+	}
+
+	for _, g := range groups {
+		g.Cost = float32(costs[g])
+	}
+
+	return groups, nil
+}
+
+func Key(s *exoclick.Statistic, fields ...exoclick.StatisticsField) string {
+	return s.Key(fields...)
+}
+
+func add(dst, src *exoclick.Statistic) {
+	dst.Clicks += src.Clicks
+	dst.Impressions += src.Impressions
+	dst.VideoImpressions += src.VideoImpressions
+	dst.VideoViews += src.VideoViews
+	dst.G1 += src.G1
+	dst.G5 += src.G5
+}
+
+func copyDimension(dst, src *exoclick.Statistic, field exoclick.StatisticsField) {
+	switch field {
+	case exoclick.Date:
+		dst.Date = src.Date
+	case exoclick.Hour:
+		dst.Hour = src.Hour
+	case exoclick.CampaignID:
+		dst.CampaignID = src.CampaignID
+	case exoclick.VariationID:
+		dst.VariationID = src.VariationID
+	case exoclick.SiteID:
+		dst.SiteID = src.SiteID
+	case exoclick.SiteName:
+		dst.SiteName = src.SiteName
+	case exoclick.ZoneID:
+		dst.ZoneID = src.ZoneID
+	case exoclick.ZoneName:
+		dst.ZoneName = src.ZoneName
+	case exoclick.CategoryID:
+		dst.CategoryID = src.CategoryID
+	}
+}
@@ -0,0 +1,79 @@
+package analysis
+
+import (
+	"slices"
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/adam-szerdahelyi/go-exoclick/exoclick"
+)
+
+func TestRebucketAcrossDST(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wallHours := func(skip ...int) []int {
+		var hours []int
+		for h := 0; h < 24; h++ {
+			if !slices.Contains(skip, h) {
+				hours = append(hours, h)
+			}
+		}
+		return hours
+	}
+
+	tests := []struct {
+		name        string
+		date        time.Time
+		hours       []int
+		impressions map[int]int
+	}{
+		{"spring forward", time.Date(2024, time.March, 31, 0, 0, 0, 0, loc), wallHours(2), nil},
+		{"fall back", time.Date(2024, time.October, 27, 0, 0, 0, 0, loc), wallHours(), map[int]int{2: 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var rows []*exoclick.Statistic
+
+			for _, ts := range exoclick.DayHours(tt.date, loc) {
+				ts = ts.UTC()
+				date := time.Date(ts.Year(), ts.Month(), ts.Day(), 0, 0, 0, 0, time.UTC)
+				hour := ts.Hour()
+
+				rows = append(rows, &exoclick.Statistic{Date: &date, Hour: &hour, Impressions: 1})
+			}
+
+			buckets, err := Rebucket(rows, loc)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(buckets) != len(tt.hours) {
+				t.Fatalf("expected %d buckets, but got %d", len(tt.hours), len(buckets))
+			}
+
+			for i, b := range buckets {
+				if b.Date.Year() != tt.date.Year() || b.Date.YearDay() != tt.date.YearDay() {
+					t.Errorf("bucket %d: expected date %s, but got %s", i, tt.date.Format("2006-01-02"), b.Date.Format("2006-01-02"))
+				}
+
+				if *b.Hour != tt.hours[i] {
+					t.Errorf("bucket %d: expected hour %d, but got %d", i, tt.hours[i], *b.Hour)
+				}
+
+				want := 1
+				if n, ok := tt.impressions[*b.Hour]; ok {
+					want = n
+				}
+
+				if b.Impressions != want {
+					t.Errorf("bucket %d: expected %d impressions, but got %d", i, want, b.Impressions)
+				}
+			}
+		})
+	}
+}
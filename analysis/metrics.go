@@ -0,0 +1,48 @@
+package analysis
+
+import (
+	"github.com/adam-szerdahelyi/go-exoclick/exoclick"
+)
+
+type Metric func(s *exoclick.Statistic) float64
+
+func Field(field exoclick.StatisticsField) Metric {
+	return func(s *exoclick.Statistic) float64 {
+		v, _ := s.Metric(field)
+		return v
+	}
+}
+
+var (
+	CTR Metric = func(s *exoclick.Statistic) float64 {
+		return Ratio(float64(s.Clicks), float64(s.Impressions))
+	}
+
+	CPC Metric = func(s *exoclick.Statistic) float64 {
+		return Ratio(float64(s.Cost), float64(s.Clicks))
+	}
+
+	CPM Metric = func(s *exoclick.Statistic) float64 {
+		return Ratio(float64(s.Cost), float64(s.Impressions)) * 1000
+	}
+
+	CVR Metric = func(s *exoclick.Statistic) float64 {
+		return Ratio(float64(s.G1), float64(s.Clicks))
+	}
+
+	CPA Metric = func(s *exoclick.Statistic) float64 {
+		return Ratio(float64(s.Cost), float64(s.G1))
+	}
+
+	ViewRate Metric = func(s *exoclick.Statistic) float64 {
+		return Ratio(float64(s.VideoViews), float64(s.VideoImpressions))
+	}
+)
+
+func Ratio(numerator, denominator float64) float64 {
+	if denominator == 0 {
+		return 0
+	}
+
+	return numerator / denominator
+}
@@ -0,0 +1,108 @@
+package analysis
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+	"strconv"
+
+	"github.com/adam-szerdahelyi/go-exoclick/exoclick"
+)
+
+type PivotTable struct {
+	RowFields []exoclick.StatisticsField
+	Columns   []string
+	Rows      []PivotRow
+}
+
+type PivotRow struct {
+	Keys   []string
+	Values []*float64
+}
+
+var numericDimensions = []exoclick.StatisticsField{
+	exoclick.Hour,
+	exoclick.CampaignID,
+	exoclick.VariationID,
+	exoclick.SiteID,
+	exoclick.ZoneID,
+	exoclick.CategoryID,
+}
+
+func (t PivotTable) String() string {
+	return exoclick.Stringify(t)
+}
+
+func Pivot(rows []*exoclick.Statistic, rowFields []exoclick.StatisticsField, columnField exoclick.StatisticsField, metric Metric) (*PivotTable, error) {
+	if metric == nil {
+		return nil, errors.New("metric must be set")
+	}
+
+	fields := append(slices.Clone(rowFields), columnField)
+
+	groups, err := GroupBy(rows, fields...)
+	if err != nil {
+		return nil, err
+	}
+
+	table := &PivotTable{RowFields: slices.Clone(rowFields)}
+
+	columnIndex := make(map[string]int)
+	rowIndex := make(map[string]int)
+
+	for _, g := range groups {
+		column, _ := g.Dimension(columnField)
+		if _, ok := columnIndex[column]; !ok {
+			columnIndex[column] = len(table.Columns)
+			table.Columns = append(table.Columns, column)
+		}
+	}
+
+	if slices.Contains(numericDimensions, columnField) {
+		slices.SortFunc(table.Columns, compareNumeric)
+	} else {
+		slices.Sort(table.Columns)
+	}
+
+	for i, column := range table.Columns {
+		columnIndex[column] = i
+	}
+
+	for _, g := range groups {
+		k := Key(g, rowFields...)
+
+		i, ok := rowIndex[k]
+		if !ok {
+			keys := make([]string, len(rowFields))
+			for j, field := range rowFields {
+				keys[j], _ = g.Dimension(field)
+			}
+
+			i = len(table.Rows)
+			rowIndex[k] = i
+			table.Rows = append(table.Rows, PivotRow{Keys: keys, Values: make([]*float64, len(table.Columns))})
+		}
+
+		column, _ := g.Dimension(columnField)
+		value := metric(g)
+		table.Rows[i].Values[columnIndex[column]] = &value
+	}
+
+	return table, nil
+}
+
+func compareNumeric(a, b string) int {
+	x, errA := strconv.Atoi(a)
+	y, errB := strconv.Atoi(b)
+
+	switch {
+	case errA != nil && errB != nil:
+		return cmp.Compare(a, b)
+	case errA != nil:
+		return -1
+	case errB != nil:
+		return 1
+	default:
+		return cmp.Compare(x, y)
+	}
+}
@@ -0,0 +1,28 @@
+package automation
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+)
+
+type AuditLog interface {
+	Record(ctx context.Context, d Decision) error
+}
+
+type JSONAuditLog struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+func NewJSONAuditLog(w io.Writer) *JSONAuditLog {
+	return &JSONAuditLog{enc: json.NewEncoder(w)}
+}
+
+func (l *JSONAuditLog) Record(_ context.Context, d Decision) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.enc.Encode(d)
+}
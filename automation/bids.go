@@ -0,0 +1,346 @@
+package automation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/adam-szerdahelyi/go-exoclick/analysis"
+	"github.com/adam-szerdahelyi/go-exoclick/exoclick"
+)
+
+const (
+	DefaultBidWindow    = 7
+	DefaultMaxStep      = 0.2
+	DefaultBidBatchSize = 500
+)
+
+type ZonePricer interface {
+	Get(ctx context.Context, id int, isDetailed bool) (*exoclick.Campaign, *http.Response, error)
+	UpdateZonePrices(ctx context.Context, campaignID int, zones []exoclick.CampaignZones) (*http.Response, error)
+}
+
+type BidOptions struct {
+	TargetCPA       float64
+	TargetROAS      float64
+	ConversionValue float64
+	Goal            exoclick.StatisticsField
+	Floor           float64
+	Ceiling         float64
+	MaxStep         float64
+	MinClicks       int
+	Window          int
+	BatchSize       int
+	DryRun          bool
+	Timezone        *time.Location
+}
+
+type BidChange struct {
+	ZoneID    int                `json:"zone_id"`
+	Current   float64            `json:"current"`
+	Target    float64            `json:"target"`
+	New       float64            `json:"new"`
+	Reason    string             `json:"reason"`
+	Statistic exoclick.Statistic `json:"statistic"`
+}
+
+type SimulationStep struct {
+	Date    time.Time   `json:"date"`
+	Changes []BidChange `json:"changes"`
+}
+
+type Simulation struct {
+	StartPrices map[int]float64  `json:"start_prices"`
+	Steps       []SimulationStep `json:"steps"`
+	Prices      map[int]float64  `json:"prices"`
+}
+
+type BidOptimizer struct {
+	fetcher Fetcher
+	zones   ZonePricer
+	opts    BidOptions
+	goal    analysis.Metric
+}
+
+func NewBidOptimizer(fetcher Fetcher, zones ZonePricer, opts BidOptions) (*BidOptimizer, error) {
+	if opts.Goal == "" {
+		opts.Goal = exoclick.G1
+	}
+
+	if opts.MaxStep == 0 {
+		opts.MaxStep = DefaultMaxStep
+	}
+
+	if opts.Window == 0 {
+		opts.Window = DefaultBidWindow
+	}
+
+	if opts.BatchSize == 0 {
+		opts.BatchSize = DefaultBidBatchSize
+	}
+
+	if opts.Timezone == nil {
+		opts.Timezone = time.UTC
+	}
+
+	var errs []error
+
+	if (opts.TargetCPA > 0) == (opts.TargetROAS > 0) {
+		errs = append(errs, errors.New("exactly one of target CPA or target ROAS must be set"))
+	}
+
+	if opts.TargetROAS > 0 && opts.ConversionValue <= 0 {
+		errs = append(errs, errors.New("conversion value is required for a target ROAS"))
+	}
+
+	if opts.Goal != exoclick.G1 && opts.Goal != exoclick.G5 {
+		errs = append(errs, fmt.Errorf("invalid goal field: \"%s\"", opts.Goal))
+	}
+
+	if opts.Floor < 0 || opts.Ceiling < 0 || (opts.Ceiling > 0 && opts.Floor > opts.Ceiling) {
+		errs = append(errs, fmt.Errorf("invalid bid limits: floor %g, ceiling %g", opts.Floor, opts.Ceiling))
+	}
+
+	if opts.MaxStep < 0 || opts.MaxStep >= 1 {
+		errs = append(errs, fmt.Errorf("invalid max step: must be between 0 and 1, but got %g", opts.MaxStep))
+	}
+
+	if opts.Window < 1 {
+		errs = append(errs, fmt.Errorf("invalid window: must be at least 1 day, but got %d", opts.Window))
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	return &BidOptimizer{
+		fetcher: fetcher,
+		zones:   zones,
+		opts:    opts,
+		goal:    analysis.Field(opts.Goal),
+	}, nil
+}
+
+func (o *BidOptimizer) Plan(ctx context.Context, campaignID int) ([]BidChange, error) {
+	prices, err := o.prices(ctx, campaignID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := zoneStatistics(ctx, o.fetcher, o.opts.Timezone, campaignID, o.opts.Window)
+	if err != nil {
+		return nil, err
+	}
+
+	return o.Bids(prices, rows), nil
+}
+
+func (o *BidOptimizer) Optimize(ctx context.Context, campaignID int) ([]BidChange, error) {
+	changes, err := o.Plan(ctx, campaignID)
+	if err != nil || o.opts.DryRun {
+		return changes, err
+	}
+
+	return changes, o.Apply(ctx, campaignID, changes)
+}
+
+func (o *BidOptimizer) Apply(ctx context.Context, campaignID int, changes []BidChange) error {
+	zones := make([]exoclick.CampaignZones, len(changes))
+	for i, change := range changes {
+		zoneID, price := change.ZoneID, change.New
+		zones[i] = exoclick.CampaignZones{ZoneID: &zoneID, Price: &price}
+	}
+
+	for batch := range slices.Chunk(zones, o.opts.BatchSize) {
+		if _, err := o.zones.UpdateZonePrices(ctx, campaignID, batch); err != nil {
+			return fmt.Errorf("updating zone prices for campaign %d: %w", campaignID, err)
+		}
+	}
+
+	return nil
+}
+
+func (o *BidOptimizer) Simulate(ctx context.Context, campaignID int, startPrices map[int]float64, from, to time.Time) (*Simulation, error) {
+	if startPrices == nil {
+		var err error
+		if startPrices, err = o.prices(ctx, campaignID); err != nil {
+			return nil, err
+		}
+	}
+
+	opts, err := exoclick.NewReport().
+		Campaign(campaignID).
+		GroupBy(exoclick.Date, exoclick.ZoneID).
+		Metrics(exoclick.MetricFields...).
+		Timezone(o.opts.Timezone).
+		Between(from.AddDate(0, 0, -o.opts.Window), to).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, _, err := o.fetcher.GetStatisticsCSV(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("fetching zone statistics for campaign %d: %w", campaignID, err)
+	}
+
+	return o.Replay(startPrices, rows, from, to)
+}
+
+func (o *BidOptimizer) Replay(prices map[int]float64, rows []*exoclick.Statistic, from, to time.Time) (*Simulation, error) {
+	sim := &Simulation{
+		StartPrices: make(map[int]float64, len(prices)),
+		Prices:      make(map[int]float64, len(prices)),
+	}
+
+	for zoneID, price := range prices {
+		sim.StartPrices[zoneID] = price
+		sim.Prices[zoneID] = price
+	}
+
+	for day, last := calendarDate(from.In(o.opts.Timezone)), calendarDate(to.In(o.opts.Timezone)); !day.After(last); day = day.AddDate(0, 0, 1) {
+		start := day.AddDate(0, 0, -o.opts.Window)
+
+		var window []*exoclick.Statistic
+		for _, row := range rows {
+			if row.Date == nil {
+				continue
+			}
+
+			if date := calendarDate(*row.Date); !date.Before(start) && date.Before(day) {
+				window = append(window, row)
+			}
+		}
+
+		window, err := analysis.GroupBy(window, exoclick.ZoneID)
+		if err != nil {
+			return nil, err
+		}
+
+		changes := o.Bids(sim.Prices, window)
+		for _, change := range changes {
+			sim.Prices[change.ZoneID] = change.New
+		}
+
+		sim.Steps = append(sim.Steps, SimulationStep{
+			Date:    time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, o.opts.Timezone),
+			Changes: changes,
+		})
+	}
+
+	return sim, nil
+}
+
+func (o *BidOptimizer) Bids(prices map[int]float64, rows []*exoclick.Statistic) []BidChange {
+	var changes []BidChange
+
+	for _, row := range rows {
+		if row.ZoneID == nil {
+			continue
+		}
+
+		current, ok := prices[*row.ZoneID]
+		if !ok || current <= 0 || row.Clicks < o.opts.MinClicks {
+			continue
+		}
+
+		target, reason := o.target(current, row)
+		if reason == "" {
+			continue
+		}
+
+		price := o.limit(current, target)
+		if price == current {
+			continue
+		}
+
+		changes = append(changes, BidChange{
+			ZoneID:    *row.ZoneID,
+			Current:   current,
+			Target:    target,
+			New:       price,
+			Reason:    reason,
+			Statistic: *row,
+		})
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].ZoneID < changes[j].ZoneID })
+
+	return changes
+}
+
+func (o *BidOptimizer) target(current float64, s *exoclick.Statistic) (float64, string) {
+	cost := float64(s.Cost)
+	conversions := o.goal(s)
+
+	if cost <= 0 {
+		return 0, ""
+	}
+
+	if o.opts.TargetROAS > 0 {
+		if conversions == 0 {
+			if cost < o.opts.ConversionValue/o.opts.TargetROAS {
+				return 0, ""
+			}
+
+			return 0, fmt.Sprintf("no conversions after %.2f spend", cost)
+		}
+
+		roas := conversions * o.opts.ConversionValue / cost
+
+		return current * roas / o.opts.TargetROAS, fmt.Sprintf("ROAS %.2f vs target %.2f", roas, o.opts.TargetROAS)
+	}
+
+	if conversions == 0 {
+		if cost < o.opts.TargetCPA {
+			return 0, ""
+		}
+
+		return 0, fmt.Sprintf("no conversions after %.2f spend", cost)
+	}
+
+	cpa := cost / conversions
+
+	return current * o.opts.TargetCPA / cpa, fmt.Sprintf("CPA %.2f vs target %.2f", cpa, o.opts.TargetCPA)
+}
+
+func (o *BidOptimizer) limit(current, target float64) float64 {
+	price := math.Max(target, current*(1-o.opts.MaxStep))
+	price = math.Min(price, current*(1+o.opts.MaxStep))
+
+	if o.opts.Ceiling > 0 {
+		price = math.Min(price, o.opts.Ceiling)
+	}
+
+	price = math.Max(price, o.opts.Floor)
+
+	return math.Round(price*10000) / 10000
+}
+
+func (o *BidOptimizer) prices(ctx context.Context, campaignID int) (map[int]float64, error) {
+	campaign, _, err := o.zones.Get(ctx, campaignID, true)
+	if err != nil {
+		return nil, fmt.Errorf("fetching campaign %d: %w", campaignID, err)
+	}
+
+	prices := make(map[int]float64)
+
+	if campaign.CampaignZones != nil {
+		for _, zone := range *campaign.CampaignZones {
+			if zone.ZoneID != nil && zone.Price != nil {
+				prices[*zone.ZoneID] = *zone.Price
+			}
+		}
+	}
+
+	return prices, nil
+}
+
+func calendarDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,190 @@
+package automation
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/adam-szerdahelyi/go-exoclick/analysis"
+	"github.com/adam-szerdahelyi/go-exoclick/exoclick"
+)
+
+type Condition func(s *exoclick.Statistic) bool
+
+var kpis = map[string]analysis.Metric{
+	"ctr": analysis.CTR,
+	"cpc": analysis.CPC,
+	"cpm": analysis.CPM,
+	"cvr": analysis.CVR,
+	"cpa": analysis.CPA,
+}
+
+func ParseCondition(expr string) (Condition, error) {
+	p := &parser{tokens: tokenize(expr)}
+
+	cond, err := p.or()
+	if err != nil {
+		return nil, fmt.Errorf("invalid condition %q: %w", expr, err)
+	}
+
+	if p.pos != len(p.tokens) {
+		return nil, fmt.Errorf("invalid condition %q: unexpected %q", expr, p.tokens[p.pos])
+	}
+
+	return cond, nil
+}
+
+func tokenize(expr string) []string {
+	var tokens []string
+
+	for i := 0; i < len(expr); {
+		r := rune(expr[i])
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(' || r == ')':
+			tokens = append(tokens, string(r))
+			i++
+		case strings.ContainsRune("<>=!&|", r):
+			j := i + 1
+			for j < len(expr) && strings.ContainsRune("=&|", rune(expr[j])) {
+				j++
+			}
+			tokens = append(tokens, expr[i:j])
+			i = j
+		default:
+			j := i
+			for j < len(expr) && !unicode.IsSpace(rune(expr[j])) && !strings.ContainsRune("()<>=!&|", rune(expr[j])) {
+				j++
+			}
+			tokens = append(tokens, expr[i:j])
+			i = j
+		}
+	}
+
+	return tokens
+}
+
+type parser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *parser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+
+	return ""
+}
+
+func (p *parser) next() string {
+	t := p.peek()
+	p.pos++
+
+	return t
+}
+
+func (p *parser) or() (Condition, error) {
+	left, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek() == "||" {
+		p.next()
+
+		right, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+
+		l := left
+		left = func(s *exoclick.Statistic) bool { return l(s) || right(s) }
+	}
+
+	return left, nil
+}
+
+func (p *parser) and() (Condition, error) {
+	left, err := p.comparison()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek() == "&&" {
+		p.next()
+
+		right, err := p.comparison()
+		if err != nil {
+			return nil, err
+		}
+
+		l := left
+		left = func(s *exoclick.Statistic) bool { return l(s) && right(s) }
+	}
+
+	return left, nil
+}
+
+func (p *parser) comparison() (Condition, error) {
+	if p.peek() == "(" {
+		p.next()
+
+		cond, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+
+		if p.next() != ")" {
+			return nil, fmt.Errorf("missing \")\"")
+		}
+
+		return cond, nil
+	}
+
+	name := strings.ToLower(p.next())
+	if name == "" {
+		return nil, fmt.Errorf("unexpected end of condition")
+	}
+
+	metric, ok := kpis[name]
+	if !ok {
+		field := exoclick.StatisticsField(name)
+		if _, ok := new(exoclick.Statistic).Metric(field); !ok {
+			return nil, fmt.Errorf("unknown metric %q", name)
+		}
+
+		metric = analysis.Field(field)
+	}
+
+	op := p.next()
+
+	value, err := strconv.ParseFloat(p.next(), 64)
+	if err != nil {
+		return nil, fmt.Errorf("expected number after %s %s", name, op)
+	}
+
+	var compare func(a, b float64) bool
+
+	switch op {
+	case ">":
+		compare = func(a, b float64) bool { return a > b }
+	case ">=":
+		compare = func(a, b float64) bool { return a >= b }
+	case "<":
+		compare = func(a, b float64) bool { return a < b }
+	case "<=":
+		compare = func(a, b float64) bool { return a <= b }
+	case "==":
+		compare = func(a, b float64) bool { return a == b }
+	case "!=":
+		compare = func(a, b float64) bool { return a != b }
+	default:
+		return nil, fmt.Errorf("unknown operator %q", op)
+	}
+
+	return func(s *exoclick.Statistic) bool { return compare(metric(s), value) }, nil
+}
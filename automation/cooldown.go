@@ -0,0 +1,32 @@
+package automation
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/adam-szerdahelyi/go-exoclick/filestore"
+)
+
+type CooldownStore interface {
+	Load() (map[string]time.Time, error)
+	Save(actions map[string]time.Time) error
+}
+
+type FileCooldownStore = filestore.JSON[time.Time]
+
+func NewFileCooldownStore(path string) *FileCooldownStore {
+	return filestore.NewJSON[time.Time](path)
+}
+
+func (k zoneKey) String() string {
+	return fmt.Sprintf("%d:%d", k.campaignID, k.zoneID)
+}
+
+func parseZoneKey(s string) (zoneKey, error) {
+	var k zoneKey
+	if _, err := fmt.Sscanf(s, "%d:%d", &k.campaignID, &k.zoneID); err != nil {
+		return zoneKey{}, fmt.Errorf("invalid cooldown key %q", s)
+	}
+
+	return k, nil
+}
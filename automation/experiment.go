@@ -0,0 +1,451 @@
+package automation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/adam-szerdahelyi/go-exoclick/analysis"
+	"github.com/adam-szerdahelyi/go-exoclick/exoclick"
+)
+
+const (
+	DefaultExperimentWindow = 14
+	DefaultConfidence       = 0.95
+	DefaultMinSamples       = 1000
+	DefaultBayesianDraws    = 20000
+)
+
+type ExperimentMetric string
+
+const (
+	TestCTR ExperimentMetric = "ctr"
+	TestCVR ExperimentMetric = "cvr"
+)
+
+type TestMethod string
+
+const (
+	Frequentist TestMethod = "frequentist"
+	Bayesian    TestMethod = "bayesian"
+	BothMethods TestMethod = "both"
+)
+
+type VariationManager interface {
+	Get(ctx context.Context, id int, isDetailed bool) (*exoclick.Campaign, *http.Response, error)
+	ChangeVariationStatus(ctx context.Context, campaignID int, variationID int, opts exoclick.Operation) (*http.Response, error)
+}
+
+type ExperimentOptions struct {
+	Metric     ExperimentMetric
+	Goal       exoclick.StatisticsField
+	Method     TestMethod
+	Confidence float64
+	MinSamples int
+	Window     int
+	Draws      int
+	DryRun     bool
+	Timezone   *time.Location
+}
+
+type VariationResult struct {
+	VariationID     int     `json:"variation_id"`
+	Samples         int     `json:"samples"`
+	Successes       int     `json:"successes"`
+	Rate            float64 `json:"rate"`
+	Lift            float64 `json:"lift"`
+	PValue          float64 `json:"p_value"`
+	ProbabilityBest float64 `json:"probability_best"`
+	ProbabilityLoss float64 `json:"probability_loss"`
+	Eligible        bool    `json:"eligible"`
+	Leader          bool    `json:"leader,omitempty"`
+	Loser           bool    `json:"loser,omitempty"`
+	Paused          bool    `json:"paused,omitempty"`
+	Error           string  `json:"error,omitempty"`
+}
+
+type ExperimentReport struct {
+	CampaignID  int               `json:"campaign_id"`
+	Metric      ExperimentMetric  `json:"metric"`
+	Method      TestMethod        `json:"method"`
+	Confidence  float64           `json:"confidence"`
+	Alpha       float64           `json:"alpha"`
+	From        time.Time         `json:"from"`
+	To          time.Time         `json:"to"`
+	Significant bool              `json:"significant"`
+	DryRun      bool              `json:"dry_run,omitempty"`
+	Variations  []VariationResult `json:"variations"`
+	Notes       []string          `json:"notes,omitempty"`
+}
+
+type Experiment struct {
+	fetcher    Fetcher
+	variations VariationManager
+	opts       ExperimentOptions
+	goal       analysis.Metric
+}
+
+func NewExperiment(fetcher Fetcher, variations VariationManager, opts ExperimentOptions) (*Experiment, error) {
+	if opts.Metric == "" {
+		opts.Metric = TestCTR
+	}
+
+	if opts.Goal == "" {
+		opts.Goal = exoclick.G1
+	}
+
+	if opts.Method == "" {
+		opts.Method = BothMethods
+	}
+
+	if opts.Confidence == 0 {
+		opts.Confidence = DefaultConfidence
+	}
+
+	if opts.MinSamples == 0 {
+		opts.MinSamples = DefaultMinSamples
+	}
+
+	if opts.Window == 0 {
+		opts.Window = DefaultExperimentWindow
+	}
+
+	if opts.Draws == 0 {
+		opts.Draws = DefaultBayesianDraws
+	}
+
+	if opts.Timezone == nil {
+		opts.Timezone = time.UTC
+	}
+
+	var errs []error
+
+	if opts.Metric != TestCTR && opts.Metric != TestCVR {
+		errs = append(errs, fmt.Errorf("invalid experiment metric: \"%s\"", opts.Metric))
+	}
+
+	if opts.Goal != exoclick.G1 && opts.Goal != exoclick.G5 {
+		errs = append(errs, fmt.Errorf("invalid goal field: \"%s\"", opts.Goal))
+	}
+
+	if opts.Method != Frequentist && opts.Method != Bayesian && opts.Method != BothMethods {
+		errs = append(errs, fmt.Errorf("invalid test method: \"%s\"", opts.Method))
+	}
+
+	if opts.Confidence <= 0.5 || opts.Confidence >= 1 {
+		errs = append(errs, fmt.Errorf("invalid confidence: must be between 0.5 and 1, but got %g", opts.Confidence))
+	}
+
+	if opts.MinSamples < 1 {
+		errs = append(errs, fmt.Errorf("invalid min samples: must be positive, but got %d", opts.MinSamples))
+	}
+
+	if opts.Window < 1 {
+		errs = append(errs, fmt.Errorf("invalid window: must be at least 1 day, but got %d", opts.Window))
+	}
+
+	if opts.Draws < 1 {
+		errs = append(errs, fmt.Errorf("invalid draws: must be positive, but got %d", opts.Draws))
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	return &Experiment{
+		fetcher:    fetcher,
+		variations: variations,
+		opts:       opts,
+		goal:       analysis.Field(opts.Goal),
+	}, nil
+}
+
+func (e *Experiment) Evaluate(ctx context.Context, campaignID int) (*ExperimentReport, error) {
+	report, err := e.Analyze(ctx, campaignID)
+	if err != nil {
+		return nil, err
+	}
+
+	report.DryRun = e.opts.DryRun
+
+	if e.opts.DryRun || !report.Significant {
+		return report, nil
+	}
+
+	var errs []error
+
+	for i := range report.Variations {
+		v := &report.Variations[i]
+		if !v.Loser {
+			continue
+		}
+
+		if _, err := e.variations.ChangeVariationStatus(ctx, campaignID, v.VariationID, exoclick.Pause); err != nil {
+			v.Error = err.Error()
+			errs = append(errs, fmt.Errorf("pausing variation %d of campaign %d: %w", v.VariationID, campaignID, err))
+			continue
+		}
+
+		v.Paused = true
+	}
+
+	return report, errors.Join(errs...)
+}
+
+func (e *Experiment) Analyze(ctx context.Context, campaignID int) (*ExperimentReport, error) {
+	campaign, _, err := e.variations.Get(ctx, campaignID, true)
+	if err != nil {
+		return nil, fmt.Errorf("fetching variations of campaign %d: %w", campaignID, err)
+	}
+
+	active := make(map[int]bool)
+	if campaign.Variations != nil {
+		for _, v := range *campaign.Variations {
+			if v.Active != 0 {
+				active[v.ID] = true
+			}
+		}
+	}
+
+	opts, err := exoclick.NewReport().
+		Campaign(campaignID).
+		GroupBy(exoclick.VariationID).
+		Metrics(exoclick.Impressions, exoclick.Clicks, e.opts.Goal).
+		Timezone(e.opts.Timezone).
+		Last(time.Duration(e.opts.Window) * exoclick.Day).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, _, err := e.fetcher.GetStatisticsCSV(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("fetching variation statistics for campaign %d: %w", campaignID, err)
+	}
+
+	rows, err = analysis.GroupBy(rows, exoclick.VariationID)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		live   []*exoclick.Statistic
+		paused []int
+	)
+
+	for _, row := range rows {
+		if row.VariationID == nil {
+			continue
+		}
+
+		if !active[*row.VariationID] {
+			paused = append(paused, *row.VariationID)
+			continue
+		}
+
+		live = append(live, row)
+	}
+
+	report := e.Test(live)
+
+	sort.Ints(paused)
+	for _, id := range paused {
+		report.Notes = append(report.Notes, fmt.Sprintf("variation %d is not active and was excluded", id))
+	}
+	report.CampaignID = campaignID
+	report.From = opts.Filter.DateFrom.Time
+	report.To = opts.Filter.DateTo.Time
+
+	return report, nil
+}
+
+func (e *Experiment) Test(rows []*exoclick.Statistic) *ExperimentReport {
+	report := &ExperimentReport{
+		Metric:     e.opts.Metric,
+		Method:     e.opts.Method,
+		Confidence: e.opts.Confidence,
+	}
+
+	var eligible []int
+
+	for _, row := range rows {
+		if row.VariationID == nil {
+			continue
+		}
+
+		samples, successes := row.Impressions, row.Clicks
+		if e.opts.Metric == TestCVR {
+			samples, successes = row.Clicks, int(e.goal(row))
+		}
+
+		if successes > samples {
+			report.Notes = append(report.Notes, fmt.Sprintf("variation %d has %d successes for %d samples; successes were capped at the sample count", *row.VariationID, successes, samples))
+			successes = samples
+		}
+
+		v := VariationResult{
+			VariationID: *row.VariationID,
+			Samples:     samples,
+			Successes:   successes,
+			Rate:        analysis.Ratio(float64(successes), float64(samples)),
+			Eligible:    samples >= e.opts.MinSamples,
+		}
+
+		if !v.Eligible {
+			report.Notes = append(report.Notes, fmt.Sprintf("variation %d has %d samples, below the minimum of %d", v.VariationID, samples, e.opts.MinSamples))
+		}
+
+		report.Variations = append(report.Variations, v)
+	}
+
+	sort.Slice(report.Variations, func(i, j int) bool {
+		return report.Variations[i].VariationID < report.Variations[j].VariationID
+	})
+
+	for i, v := range report.Variations {
+		if v.Eligible {
+			eligible = append(eligible, i)
+		}
+	}
+
+	if len(eligible) < 2 {
+		report.Notes = append(report.Notes, "at least two variations with enough samples are required")
+		return report
+	}
+
+	leader := eligible[0]
+	for _, i := range eligible[1:] {
+		if report.Variations[i].Rate > report.Variations[leader].Rate {
+			leader = i
+		}
+	}
+
+	best := &report.Variations[leader]
+	best.Leader = true
+
+	report.Alpha = (1 - e.opts.Confidence) / float64(len(eligible)-1)
+
+	probBest, probLoss := e.posterior(report.Variations, eligible, leader)
+
+	for n, i := range eligible {
+		v := &report.Variations[i]
+		v.ProbabilityBest = probBest[n]
+
+		if i == leader {
+			continue
+		}
+
+		v.ProbabilityLoss = probLoss[n]
+		v.PValue = twoProportionPValue(v.Successes, v.Samples, best.Successes, best.Samples)
+
+		if best.Rate > 0 {
+			v.Lift = v.Rate/best.Rate - 1
+		}
+
+		frequentist := v.PValue < report.Alpha
+		bayesian := v.ProbabilityLoss >= e.opts.Confidence
+
+		switch e.opts.Method {
+		case Frequentist:
+			v.Loser = frequentist
+		case Bayesian:
+			v.Loser = bayesian
+		case BothMethods:
+			v.Loser = frequentist && bayesian
+		}
+
+		report.Significant = report.Significant || v.Loser
+	}
+
+	return report
+}
+
+func (e *Experiment) posterior(variations []VariationResult, eligible []int, leader int) (best, loss []float64) {
+	rng := rand.New(rand.NewPCG(1, 2))
+
+	best = make([]float64, len(eligible))
+	loss = make([]float64, len(eligible))
+	draws := make([]float64, len(eligible))
+
+	for range e.opts.Draws {
+		top, leaderDraw := 0, 0.0
+
+		for n, i := range eligible {
+			v := variations[i]
+			draws[n] = sampleBeta(rng, float64(v.Successes+1), float64(v.Samples-v.Successes+1))
+
+			if draws[n] > draws[top] {
+				top = n
+			}
+
+			if i == leader {
+				leaderDraw = draws[n]
+			}
+		}
+
+		best[top]++
+
+		for n := range eligible {
+			if leaderDraw > draws[n] {
+				loss[n]++
+			}
+		}
+	}
+
+	for n := range eligible {
+		best[n] /= float64(e.opts.Draws)
+		loss[n] /= float64(e.opts.Draws)
+	}
+
+	return best, loss
+}
+
+func twoProportionPValue(s1, n1, s2, n2 int) float64 {
+	p1, p2 := analysis.Ratio(float64(s1), float64(n1)), analysis.Ratio(float64(s2), float64(n2))
+	pooled := analysis.Ratio(float64(s1+s2), float64(n1+n2))
+
+	se := math.Sqrt(pooled * (1 - pooled) * (1/float64(n1) + 1/float64(n2)))
+	if se == 0 {
+		return 1
+	}
+
+	z := (p1 - p2) / se
+
+	return math.Erfc(math.Abs(z) / math.Sqrt2)
+}
+
+func sampleBeta(rng *rand.Rand, a, b float64) float64 {
+	x := sampleGamma(rng, a)
+	y := sampleGamma(rng, b)
+
+	return x / (x + y)
+}
+
+func sampleGamma(rng *rand.Rand, shape float64) float64 {
+	if shape < 1 {
+		return sampleGamma(rng, shape+1) * math.Pow(rng.Float64(), 1/shape)
+	}
+
+	d := shape - 1.0/3
+	c := 1 / math.Sqrt(9*d)
+
+	for {
+		x := rng.NormFloat64()
+		v := 1 + c*x
+		if v <= 0 {
+			continue
+		}
+
+		v = v * v * v
+		u := rng.Float64()
+
+		if math.Log(u) < 0.5*x*x+d-d*v+d*math.Log(v) {
+			return d * v
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package automation
+
+import (
+	"math"
+	"testing"
+
+	"github.com/adam-szerdahelyi/go-exoclick/exoclick"
+)
+
+func TestExperimentCapsConversionsAtClicks(t *testing.T) {
+	e, err := NewExperiment(nil, nil, ExperimentOptions{Metric: TestCVR, MinSamples: 10, Draws: 1000})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a, b := 1, 2
+	report := e.Test([]*exoclick.Statistic{
+		{VariationID: &a, Clicks: 100, G1: 150},
+		{VariationID: &b, Clicks: 100, G1: 20},
+	})
+
+	if len(report.Variations) != 2 {
+		t.Fatalf("expected 2 variations, but got %d", len(report.Variations))
+	}
+
+	if v := report.Variations[0]; v.Successes != v.Samples {
+		t.Errorf("expected successes to be capped at %d, but got %d", v.Samples, v.Successes)
+	}
+
+	for _, v := range report.Variations {
+		if math.IsNaN(v.PValue) || math.IsNaN(v.ProbabilityLoss) || math.IsNaN(v.ProbabilityBest) {
+			t.Errorf("variation %d: unexpected NaN in %+v", v.VariationID, v)
+		}
+	}
+
+	if !report.Variations[1].Loser {
+		t.Errorf("expected variation %d to lose, but got %+v", b, report.Variations[1])
+	}
+}
@@ -0,0 +1,502 @@
+package automation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/adam-szerdahelyi/go-exoclick/exoclick"
+	"github.com/adam-szerdahelyi/go-exoclick/filestore"
+)
+
+const (
+	DefaultPacingInterval  = 15 * time.Minute
+	DefaultPacingTolerance = 0.1
+	DefaultPacingBidStep   = 0.1
+	DefaultMinBidFactor    = 0.5
+	DefaultRunRateHours    = 3
+)
+
+type PacingMode string
+
+const (
+	PauseResume PacingMode = "pause_resume"
+	AdjustBids  PacingMode = "adjust_bids"
+)
+
+type PacingAction string
+
+const (
+	NoAction     PacingAction = "none"
+	PauseAction  PacingAction = "pause"
+	ResumeAction PacingAction = "resume"
+	LowerBids    PacingAction = "lower_bids"
+	RaiseBids    PacingAction = "raise_bids"
+)
+
+type CampaignManager interface {
+	ZonePricer
+	ChangeCampaignStatus(ctx context.Context, opts exoclick.Operation, campaignIDs ...int) (*http.Response, error)
+}
+
+type PacingOptions struct {
+	Targets      map[int]float64
+	Mode         PacingMode
+	Tolerance    float64
+	BidStep      float64
+	MinBidFactor float64
+	RunRateHours int
+	Interval     time.Duration
+	DryRun       bool
+	Timezone     *time.Location
+	Store        PacingStore
+	OnDecision   func(PacingDecision)
+	OnError      func(error)
+}
+
+type PacingDecision struct {
+	Time       time.Time    `json:"time"`
+	CampaignID int          `json:"campaign_id"`
+	Target     float64      `json:"target"`
+	Spend      float64      `json:"spend"`
+	Expected   float64      `json:"expected"`
+	Projected  float64      `json:"projected"`
+	Action     PacingAction `json:"action"`
+	DryRun     bool         `json:"dry_run,omitempty"`
+	Error      string       `json:"error,omitempty"`
+}
+
+type PacingState struct {
+	Day       time.Time `json:"day"`
+	Paused    bool      `json:"paused"`
+	BidFactor float64   `json:"bid_factor"`
+}
+
+type PacingStore interface {
+	Load() (map[string]PacingState, error)
+	Save(states map[string]PacingState) error
+}
+
+type FilePacingStore = filestore.JSON[PacingState]
+
+func NewFilePacingStore(path string) *FilePacingStore {
+	return filestore.NewJSON[PacingState](path)
+}
+
+type PacingController struct {
+	fetcher   Fetcher
+	campaigns CampaignManager
+	opts      PacingOptions
+	now       func() time.Time
+
+	mu    sync.Mutex
+	state map[int]*PacingState
+}
+
+func NewPacingController(fetcher Fetcher, campaigns CampaignManager, opts PacingOptions) (*PacingController, error) {
+	if opts.Mode == "" {
+		opts.Mode = PauseResume
+	}
+
+	if opts.Tolerance == 0 {
+		opts.Tolerance = DefaultPacingTolerance
+	}
+
+	if opts.BidStep == 0 {
+		opts.BidStep = DefaultPacingBidStep
+	}
+
+	if opts.MinBidFactor == 0 {
+		opts.MinBidFactor = DefaultMinBidFactor
+	}
+
+	if opts.RunRateHours == 0 {
+		opts.RunRateHours = DefaultRunRateHours
+	}
+
+	if opts.Interval == 0 {
+		opts.Interval = DefaultPacingInterval
+	}
+
+	if opts.Timezone == nil {
+		opts.Timezone = time.UTC
+	}
+
+	var errs []error
+
+	if len(opts.Targets) == 0 {
+		errs = append(errs, errors.New("at least one campaign target is required"))
+	}
+
+	for campaignID, target := range opts.Targets {
+		if target <= 0 {
+			errs = append(errs, fmt.Errorf("invalid target for campaign %d: must be positive, but got %g", campaignID, target))
+		}
+	}
+
+	if opts.Mode != PauseResume && opts.Mode != AdjustBids {
+		errs = append(errs, fmt.Errorf("invalid pacing mode: \"%s\"", opts.Mode))
+	}
+
+	if opts.Tolerance < 0 || opts.Tolerance >= 1 {
+		errs = append(errs, fmt.Errorf("invalid tolerance: must be between 0 and 1, but got %g", opts.Tolerance))
+	}
+
+	if opts.BidStep < 0 || opts.BidStep >= 1 {
+		errs = append(errs, fmt.Errorf("invalid bid step: must be between 0 and 1, but got %g", opts.BidStep))
+	}
+
+	if opts.MinBidFactor < 0 || opts.MinBidFactor > 1 {
+		errs = append(errs, fmt.Errorf("invalid minimum bid factor: must be between 0 and 1, but got %g", opts.MinBidFactor))
+	}
+
+	if opts.RunRateHours < 1 {
+		errs = append(errs, fmt.Errorf("invalid run rate hours: must be at least 1, but got %d", opts.RunRateHours))
+	}
+
+	if opts.Interval < 0 {
+		errs = append(errs, fmt.Errorf("invalid interval: must be positive, but got %s", opts.Interval))
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	p := &PacingController{
+		fetcher:   fetcher,
+		campaigns: campaigns,
+		opts:      opts,
+		now:       time.Now,
+		state:     make(map[int]*PacingState),
+	}
+
+	if opts.Store != nil {
+		states, err := opts.Store.Load()
+		if err != nil {
+			return nil, fmt.Errorf("loading pacing state: %w", err)
+		}
+
+		for key, state := range states {
+			campaignID, err := strconv.Atoi(key)
+			if err != nil {
+				return nil, fmt.Errorf("loading pacing state: invalid campaign id %q", key)
+			}
+
+			if _, ok := opts.Targets[campaignID]; ok {
+				state := state
+				p.state[campaignID] = &state
+			}
+		}
+	}
+
+	return p, nil
+}
+
+func (p *PacingController) Run(ctx context.Context) error {
+	ticker := time.NewTicker(p.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := p.Step(ctx); err != nil && p.opts.OnError != nil {
+			p.opts.OnError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *PacingController) Step(ctx context.Context) ([]PacingDecision, error) {
+	opts, err := exoclick.NewReport().
+		GroupBy(exoclick.CampaignID, exoclick.Hour).
+		Metrics(exoclick.Cost).
+		Timezone(p.opts.Timezone).
+		Today().
+		Build()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, _, err := p.fetcher.GetStatisticsCSV(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("fetching hourly statistics: %w", err)
+	}
+
+	now := p.now().In(p.opts.Timezone)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	hourly := make(map[int][]float64)
+	for _, row := range rows {
+		if row.CampaignID == nil || row.Hour == nil || *row.Hour < 0 || *row.Hour >= 24 {
+			continue
+		}
+
+		spend, ok := hourly[*row.CampaignID]
+		if !ok {
+			spend = make([]float64, 24)
+			hourly[*row.CampaignID] = spend
+		}
+
+		spend[*row.Hour] += float64(row.Cost)
+	}
+
+	campaignIDs := make([]int, 0, len(p.opts.Targets))
+	for campaignID := range p.opts.Targets {
+		campaignIDs = append(campaignIDs, campaignID)
+	}
+
+	sort.Ints(campaignIDs)
+
+	var errs []error
+
+	decisions := make([]PacingDecision, 0, len(campaignIDs))
+	for _, campaignID := range campaignIDs {
+		if err := p.rollover(ctx, today, campaignID); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		active := true
+		if p.opts.Mode == PauseResume {
+			campaign, _, err := p.campaigns.Get(ctx, campaignID, false)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("fetching campaign %d: %w", campaignID, err))
+				continue
+			}
+
+			if campaign.Campaign != nil && campaign.Campaign.Status != nil {
+				active = *campaign.Campaign.Status == exoclick.CampaignActive
+			}
+		}
+
+		spend := hourly[campaignID]
+		if spend == nil {
+			spend = make([]float64, 24)
+		}
+
+		d := p.decide(now, campaignID, spend, active)
+
+		if err := p.act(ctx, &d); err != nil {
+			d.Error = err.Error()
+			errs = append(errs, err)
+		}
+
+		if p.opts.OnDecision != nil {
+			p.opts.OnDecision(d)
+		}
+
+		decisions = append(decisions, d)
+	}
+
+	if err := p.save(); err != nil {
+		errs = append(errs, fmt.Errorf("saving pacing state: %w", err))
+	}
+
+	return decisions, errors.Join(errs...)
+}
+
+func (p *PacingController) save() error {
+	if p.opts.Store == nil {
+		return nil
+	}
+
+	p.mu.Lock()
+	states := make(map[string]PacingState, len(p.state))
+	for campaignID, state := range p.state {
+		states[strconv.Itoa(campaignID)] = *state
+	}
+	p.mu.Unlock()
+
+	return p.opts.Store.Save(states)
+}
+
+func (p *PacingController) decide(now time.Time, campaignID int, hourly []float64, active bool) PacingDecision {
+	target := p.opts.Targets[campaignID]
+
+	start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	dayLength := start.AddDate(0, 0, 1).Sub(start)
+	elapsed := now.Sub(start)
+
+	var spend float64
+	for _, cost := range hourly {
+		spend += cost
+	}
+
+	hour := min(now.Hour(), len(hourly))
+
+	var recent float64
+	hours := 0
+	for h := hour - 1; h >= 0 && hours < p.opts.RunRateHours; h-- {
+		recent += hourly[h]
+		hours++
+	}
+
+	rate := spend / math.Max(elapsed.Hours(), 1)
+	if hours > 0 {
+		rate = recent / float64(hours)
+	}
+
+	d := PacingDecision{
+		Time:       now,
+		CampaignID: campaignID,
+		Target:     target,
+		Spend:      spend,
+		Expected:   target * elapsed.Hours() / dayLength.Hours(),
+		Projected:  spend + rate*(dayLength-elapsed).Hours(),
+		Action:     NoAction,
+		DryRun:     p.opts.DryRun,
+	}
+
+	p.mu.Lock()
+	state := p.campaignState(campaignID)
+	if active {
+		state.Paused = false
+	}
+	current := *state
+	p.mu.Unlock()
+
+	overpacing := spend >= target || d.Projected > target*(1+p.opts.Tolerance)
+	underpacing := spend < target && d.Projected < target*(1-p.opts.Tolerance)
+
+	switch p.opts.Mode {
+	case PauseResume:
+		if !active {
+			if current.Paused && spend < target && spend < d.Expected*(1-p.opts.Tolerance) {
+				d.Action = ResumeAction
+			}
+		} else if overpacing {
+			d.Action = PauseAction
+		}
+	case AdjustBids:
+		if overpacing && current.BidFactor > p.opts.MinBidFactor {
+			d.Action = LowerBids
+		} else if underpacing && current.BidFactor < 1 {
+			d.Action = RaiseBids
+		}
+	}
+
+	return d
+}
+
+func (p *PacingController) rollover(ctx context.Context, day time.Time, campaignID int) error {
+	p.mu.Lock()
+	state := p.campaignState(campaignID)
+	previous := *state
+	p.mu.Unlock()
+
+	if previous.Day.Equal(day) {
+		return nil
+	}
+
+	if !previous.Day.IsZero() && !p.opts.DryRun {
+		if previous.Paused {
+			if _, err := p.campaigns.ChangeCampaignStatus(ctx, exoclick.Play, campaignID); err != nil {
+				return fmt.Errorf("resuming campaign %d at day rollover: %w", campaignID, err)
+			}
+		}
+
+		if previous.BidFactor != 1 {
+			if err := p.scaleBids(ctx, campaignID, 1/previous.BidFactor); err != nil {
+				return fmt.Errorf("restoring bids at day rollover: %w", err)
+			}
+		}
+	}
+
+	p.mu.Lock()
+	*state = PacingState{Day: day, BidFactor: 1}
+	p.mu.Unlock()
+
+	return nil
+}
+
+func (p *PacingController) act(ctx context.Context, d *PacingDecision) error {
+	if d.Action == NoAction || p.opts.DryRun {
+		return nil
+	}
+
+	p.mu.Lock()
+	state := p.campaignState(d.CampaignID)
+	bidFactor := state.BidFactor
+	p.mu.Unlock()
+
+	switch d.Action {
+	case PauseAction, ResumeAction:
+		op := exoclick.Pause
+		if d.Action == ResumeAction {
+			op = exoclick.Play
+		}
+
+		if _, err := p.campaigns.ChangeCampaignStatus(ctx, op, d.CampaignID); err != nil {
+			return fmt.Errorf("changing status of campaign %d to %s: %w", d.CampaignID, op, err)
+		}
+
+		p.mu.Lock()
+		state.Paused = d.Action == PauseAction
+		p.mu.Unlock()
+	case LowerBids, RaiseBids:
+		factor := math.Max(1-p.opts.BidStep, p.opts.MinBidFactor/bidFactor)
+		if d.Action == RaiseBids {
+			factor = math.Min(1/bidFactor, 1+p.opts.BidStep)
+		}
+
+		if err := p.scaleBids(ctx, d.CampaignID, factor); err != nil {
+			return err
+		}
+
+		p.mu.Lock()
+		state.BidFactor *= factor
+		p.mu.Unlock()
+	}
+
+	return nil
+}
+
+func (p *PacingController) scaleBids(ctx context.Context, campaignID int, factor float64) error {
+	campaign, _, err := p.campaigns.Get(ctx, campaignID, true)
+	if err != nil {
+		return fmt.Errorf("fetching campaign %d: %w", campaignID, err)
+	}
+
+	if campaign.CampaignZones == nil {
+		return nil
+	}
+
+	var zones []exoclick.CampaignZones
+	for _, zone := range *campaign.CampaignZones {
+		if zone.ZoneID == nil || zone.Price == nil {
+			continue
+		}
+
+		zoneID, price := *zone.ZoneID, math.Round(*zone.Price*factor*10000)/10000
+		zones = append(zones, exoclick.CampaignZones{ZoneID: &zoneID, Price: &price})
+	}
+
+	if len(zones) == 0 {
+		return nil
+	}
+
+	if _, err := p.campaigns.UpdateZonePrices(ctx, campaignID, zones); err != nil {
+		return fmt.Errorf("updating zone prices for campaign %d: %w", campaignID, err)
+	}
+
+	return nil
+}
+
+func (p *PacingController) campaignState(campaignID int) *PacingState {
+	state, ok := p.state[campaignID]
+	if !ok {
+		state = &PacingState{BidFactor: 1}
+		p.state[campaignID] = state
+	}
+
+	return state
+}
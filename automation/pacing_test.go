@@ -0,0 +1,111 @@
+package automation
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/adam-szerdahelyi/go-exoclick/exoclick"
+)
+
+type fakeSpend struct {
+	cost float32
+}
+
+func (f *fakeSpend) GetStatisticsCSV(ctx context.Context, opts *exoclick.StatisticsOptions) ([]*exoclick.Statistic, *http.Response, error) {
+	campaignID, hour := 1, 0
+	return []*exoclick.Statistic{{CampaignID: &campaignID, Hour: &hour, Cost: f.cost}}, nil, nil
+}
+
+type fakeCampaign struct {
+	status     int
+	price      float64
+	operations []exoclick.Operation
+}
+
+func (f *fakeCampaign) Get(ctx context.Context, id int, isDetailed bool) (*exoclick.Campaign, *http.Response, error) {
+	status, zoneID, price := f.status, 5, f.price
+	return &exoclick.Campaign{
+		Campaign:      &exoclick.CampaignData{ID: &id, Status: &status},
+		CampaignZones: &[]exoclick.CampaignZones{{ZoneID: &zoneID, Price: &price}},
+	}, nil, nil
+}
+
+func (f *fakeCampaign) UpdateZonePrices(ctx context.Context, campaignID int, zones []exoclick.CampaignZones) (*http.Response, error) {
+	f.price = *zones[0].Price
+	return nil, nil
+}
+
+func (f *fakeCampaign) ChangeCampaignStatus(ctx context.Context, op exoclick.Operation, campaignIDs ...int) (*http.Response, error) {
+	f.operations = append(f.operations, op)
+	if op == exoclick.Pause {
+		f.status = exoclick.CampaignPaused
+	} else {
+		f.status = exoclick.CampaignActive
+	}
+	return nil, nil
+}
+
+func TestPacingResumesAfterRestart(t *testing.T) {
+	store := NewFilePacingStore(filepath.Join(t.TempDir(), "pacing.json"))
+	campaign := &fakeCampaign{status: exoclick.CampaignActive}
+	opts := PacingOptions{Targets: map[int]float64{1: 100}, Store: store}
+
+	p, err := NewPacingController(&fakeSpend{cost: 150}, campaign, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p.now = func() time.Time { return time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC) }
+
+	if _, err := p.Step(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if campaign.status != exoclick.CampaignPaused {
+		t.Fatalf("expected the campaign to be paused, but got status %d", campaign.status)
+	}
+
+	restarted, err := NewPacingController(&fakeSpend{cost: 0}, campaign, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	restarted.now = func() time.Time { return time.Date(2024, time.March, 2, 0, 30, 0, 0, time.UTC) }
+
+	if _, err := restarted.Step(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if campaign.status != exoclick.CampaignActive {
+		t.Errorf("expected the campaign to be resumed at day rollover, but got operations %v", campaign.operations)
+	}
+}
+
+func TestPacingStopsLoweringBidsAtFloor(t *testing.T) {
+	campaign := &fakeCampaign{status: exoclick.CampaignActive, price: 1}
+
+	p, err := NewPacingController(&fakeSpend{cost: 150}, campaign, PacingOptions{
+		Targets:      map[int]float64{1: 100},
+		Mode:         AdjustBids,
+		BidStep:      0.3,
+		MinBidFactor: 0.4,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p.now = func() time.Time { return time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC) }
+
+	for i := 0; i < 5; i++ {
+		if _, err := p.Step(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if campaign.price != 0.4 {
+		t.Errorf("expected the bid to stop at 0.4, but got %g", campaign.price)
+	}
+}
@@ -0,0 +1,305 @@
+package automation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/adam-szerdahelyi/go-exoclick/analysis"
+	"github.com/adam-szerdahelyi/go-exoclick/exoclick"
+)
+
+const DefaultCooldown = 24 * time.Hour
+
+type Action string
+
+const BlockZone Action = "block_zone"
+
+type Fetcher interface {
+	GetStatisticsCSV(ctx context.Context, opts *exoclick.StatisticsOptions) ([]*exoclick.Statistic, *http.Response, error)
+}
+
+type ZoneBlocker interface {
+	BlockZones(ctx context.Context, campaignID int, zoneIDs []int) (*http.Response, error)
+}
+
+type Rule struct {
+	Name   string
+	When   string
+	Window int
+	Action Action
+
+	cond Condition
+}
+
+type Options struct {
+	Rules     []Rule
+	Cooldown  time.Duration
+	Cooldowns CooldownStore
+	DryRun    bool
+	Audit     AuditLog
+	Timezone  *time.Location
+}
+
+type Decision struct {
+	Time       time.Time          `json:"time"`
+	Rule       string             `json:"rule"`
+	Action     Action             `json:"action"`
+	CampaignID int                `json:"campaign_id"`
+	ZoneID     int                `json:"zone_id"`
+	Statistic  exoclick.Statistic `json:"statistic"`
+	DryRun     bool               `json:"dry_run,omitempty"`
+	Skipped    string             `json:"skipped,omitempty"`
+	Error      string             `json:"error,omitempty"`
+}
+
+type Engine struct {
+	fetcher Fetcher
+	blocker ZoneBlocker
+	opts    Options
+	now     func() time.Time
+
+	mu      sync.Mutex
+	actions map[zoneKey]time.Time
+}
+
+type zoneKey struct {
+	campaignID int
+	zoneID     int
+}
+
+func NewEngine(fetcher Fetcher, blocker ZoneBlocker, opts Options) (*Engine, error) {
+	if opts.Cooldown == 0 {
+		opts.Cooldown = DefaultCooldown
+	}
+
+	if opts.Timezone == nil {
+		opts.Timezone = time.UTC
+	}
+
+	var errs []error
+
+	if len(opts.Rules) == 0 {
+		errs = append(errs, errors.New("at least one rule is required"))
+	}
+
+	if opts.Cooldown < 0 {
+		errs = append(errs, fmt.Errorf("invalid cooldown: must be positive, but got %s", opts.Cooldown))
+	}
+
+	rules := make([]Rule, len(opts.Rules))
+	for i, rule := range opts.Rules {
+		if rule.Action == "" {
+			rule.Action = BlockZone
+		}
+
+		if rule.Action != BlockZone {
+			errs = append(errs, fmt.Errorf("rule %q: unsupported action %q", rule.Name, rule.Action))
+		}
+
+		if rule.Window < 1 {
+			errs = append(errs, fmt.Errorf("rule %q: window must be at least 1 day, but got %d", rule.Name, rule.Window))
+		}
+
+		cond, err := ParseCondition(rule.When)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("rule %q: %w", rule.Name, err))
+		}
+
+		rule.cond = cond
+		rules[i] = rule
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	opts.Rules = rules
+
+	e := &Engine{
+		fetcher: fetcher,
+		blocker: blocker,
+		opts:    opts,
+		now:     time.Now,
+		actions: make(map[zoneKey]time.Time),
+	}
+
+	if opts.Cooldowns != nil {
+		actions, err := opts.Cooldowns.Load()
+		if err != nil {
+			return nil, fmt.Errorf("loading cooldowns: %w", err)
+		}
+
+		for key, t := range actions {
+			k, err := parseZoneKey(key)
+			if err != nil {
+				return nil, fmt.Errorf("loading cooldowns: %w", err)
+			}
+
+			e.actions[k] = t
+		}
+	}
+
+	return e, nil
+}
+
+func (e *Engine) Evaluate(ctx context.Context, campaignID int) ([]Decision, error) {
+	windows := make(map[int][]*exoclick.Statistic)
+
+	for _, rule := range e.opts.Rules {
+		if _, ok := windows[rule.Window]; ok {
+			continue
+		}
+
+		rows, err := zoneStatistics(ctx, e.fetcher, e.opts.Timezone, campaignID, rule.Window)
+		if err != nil {
+			return nil, err
+		}
+
+		windows[rule.Window] = rows
+	}
+
+	var (
+		decisions []Decision
+		zones     []int
+		matched   = make(map[int]bool)
+	)
+
+	now := e.now()
+
+	for _, rule := range e.opts.Rules {
+		for _, row := range windows[rule.Window] {
+			if row.ZoneID == nil || matched[*row.ZoneID] || !rule.cond(row) {
+				continue
+			}
+
+			matched[*row.ZoneID] = true
+
+			d := Decision{
+				Time:       now,
+				Rule:       rule.Name,
+				Action:     rule.Action,
+				CampaignID: campaignID,
+				ZoneID:     *row.ZoneID,
+				Statistic:  *row,
+				DryRun:     e.opts.DryRun,
+			}
+
+			if last, ok := e.lastAction(campaignID, d.ZoneID); ok && now.Sub(last) < e.opts.Cooldown {
+				d.Skipped = fmt.Sprintf("cooldown until %s", last.Add(e.opts.Cooldown).Format(time.RFC3339))
+			} else if !e.opts.DryRun {
+				zones = append(zones, d.ZoneID)
+			}
+
+			decisions = append(decisions, d)
+		}
+	}
+
+	var (
+		applyErr error
+		errs     []error
+	)
+
+	if len(zones) > 0 {
+		if _, applyErr = e.blocker.BlockZones(ctx, campaignID, zones); applyErr == nil {
+			if err := e.recordActions(campaignID, zones, now); err != nil {
+				errs = append(errs, fmt.Errorf("saving cooldowns: %w", err))
+			}
+		}
+	}
+
+	for i := range decisions {
+		if applyErr != nil && decisions[i].Skipped == "" && !decisions[i].DryRun {
+			decisions[i].Error = applyErr.Error()
+		}
+
+		if e.opts.Audit != nil {
+			if err := e.opts.Audit.Record(ctx, decisions[i]); err != nil {
+				errs = append(errs, fmt.Errorf("audit: %w", err))
+			}
+		}
+	}
+
+	if applyErr != nil {
+		errs = append([]error{fmt.Errorf("blocking zones for campaign %d: %w", campaignID, applyErr)}, errs...)
+	}
+
+	return decisions, errors.Join(errs...)
+}
+
+func (e *Engine) Run(ctx context.Context, interval time.Duration, campaignIDs []int, onError func(error)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for _, campaignID := range campaignIDs {
+			if _, err := e.Evaluate(ctx, campaignID); err != nil && onError != nil {
+				onError(err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (e *Engine) lastAction(campaignID, zoneID int) (time.Time, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	t, ok := e.actions[zoneKey{campaignID, zoneID}]
+
+	return t, ok
+}
+
+func (e *Engine) recordActions(campaignID int, zoneIDs []int, now time.Time) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, zoneID := range zoneIDs {
+		e.actions[zoneKey{campaignID, zoneID}] = now
+	}
+
+	for k, t := range e.actions {
+		if now.Sub(t) >= e.opts.Cooldown {
+			delete(e.actions, k)
+		}
+	}
+
+	if e.opts.Cooldowns == nil {
+		return nil
+	}
+
+	actions := make(map[string]time.Time, len(e.actions))
+	for k, t := range e.actions {
+		actions[k.String()] = t
+	}
+
+	return e.opts.Cooldowns.Save(actions)
+}
+
+func zoneStatistics(ctx context.Context, fetcher Fetcher, loc *time.Location, campaignID, window int) ([]*exoclick.Statistic, error) {
+	opts, err := exoclick.NewReport().
+		Campaign(campaignID).
+		GroupBy(exoclick.ZoneID).
+		Metrics(exoclick.MetricFields...).
+		Timezone(loc).
+		Last(time.Duration(window) * exoclick.Day).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, _, err := fetcher.GetStatisticsCSV(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("fetching zone statistics for campaign %d: %w", campaignID, err)
+	}
+
+	return analysis.GroupBy(rows, exoclick.ZoneID)
+}
@@ -0,0 +1,33 @@
+package campaignplan
+
+import (
+	"context"
+	"fmt"
+)
+
+type ApplyOptions struct {
+	DryRun bool
+	OnStep func(step *Step, dryRun bool)
+}
+
+func (p *Planner) Apply(ctx context.Context, plan *Plan, opts ApplyOptions) error {
+	if len(plan.Conflicts) > 0 {
+		return fmt.Errorf("plan has %d conflict(s) that cannot be applied", len(plan.Conflicts))
+	}
+
+	for i, step := range plan.Steps {
+		if opts.OnStep != nil {
+			opts.OnStep(step, opts.DryRun)
+		}
+
+		if opts.DryRun {
+			continue
+		}
+
+		if err := step.apply(ctx); err != nil {
+			return fmt.Errorf("step %d (%s) failed: %w", i+1, step.Description, err)
+		}
+	}
+
+	return nil
+}
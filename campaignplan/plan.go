@@ -0,0 +1,603 @@
+package campaignplan
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/adam-szerdahelyi/go-exoclick/exoclick"
+)
+
+const (
+	orderSettings = iota
+	orderCreate
+	orderActivate
+	orderTarget
+	orderReprice
+	orderUntarget
+	orderDeactivate
+)
+
+type Op byte
+
+const (
+	Add    Op = '+'
+	Remove Op = '-'
+	Change Op = '~'
+)
+
+type Diff struct {
+	Op   Op
+	Path string
+	Old  any
+	New  any
+}
+
+func (d Diff) String() string {
+	switch d.Op {
+	case Add:
+		return fmt.Sprintf("+ %s: %s", d.Path, format(d.New))
+	case Remove:
+		return fmt.Sprintf("- %s: %s", d.Path, format(d.Old))
+	default:
+		return fmt.Sprintf("~ %s: %s -> %s", d.Path, format(d.Old), format(d.New))
+	}
+}
+
+type Step struct {
+	Description string
+	Diffs       []Diff
+
+	order int
+	apply func(ctx context.Context) error
+}
+
+type Plan struct {
+	CampaignID int
+	Steps      []*Step
+	Conflicts  []Diff
+	Warnings   []string
+}
+
+func (p *Plan) Empty() bool {
+	return len(p.Steps) == 0 && len(p.Conflicts) == 0
+}
+
+func (p *Plan) String() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "campaign %d: ", p.CampaignID)
+	if p.Empty() {
+		b.WriteString("no changes\n")
+	} else {
+		fmt.Fprintf(&b, "%d step(s)\n", len(p.Steps))
+	}
+
+	for i, step := range p.Steps {
+		fmt.Fprintf(&b, "%d. %s\n", i+1, step.Description)
+		for _, diff := range step.Diffs {
+			fmt.Fprintf(&b, "     %s\n", diff)
+		}
+	}
+
+	for _, conflict := range p.Conflicts {
+		fmt.Fprintf(&b, "conflict: %s\n", conflict)
+	}
+
+	for _, warning := range p.Warnings {
+		fmt.Fprintf(&b, "warning: %s\n", warning)
+	}
+
+	return b.String()
+}
+
+type Planner struct {
+	campaigns *exoclick.CampaignsService
+}
+
+func NewPlanner(client *exoclick.Client) *Planner {
+	return &Planner{campaigns: client.Campaigns}
+}
+
+var readOnlySettings = []string{"id", "campaign_type", "date_created"}
+
+func (p *Planner) Plan(ctx context.Context, desired *Spec) (*Plan, error) {
+	if desired.Campaign == nil || desired.Campaign.ID == nil {
+		return nil, fmt.Errorf("desired campaign must set campaign.id")
+	}
+
+	id := *desired.Campaign.ID
+
+	live, _, err := p.campaigns.Get(ctx, id, true)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &Plan{CampaignID: id}
+
+	p.planSettings(plan, desired, live)
+	p.planVariations(plan, desired, live)
+	p.planTargeting(plan, desired, live)
+	p.planSchedule(plan, desired, live)
+
+	slices.SortStableFunc(plan.Steps, func(a, b *Step) int {
+		return a.order - b.order
+	})
+
+	return plan, nil
+}
+
+func (p *Planner) planSettings(plan *Plan, desired *Spec, live *exoclick.Campaign) {
+	want := reflect.ValueOf(desired.Campaign).Elem()
+
+	var have reflect.Value
+	if live.Campaign != nil {
+		have = reflect.ValueOf(live.Campaign).Elem()
+	}
+
+	update := new(exoclick.CampaignData)
+	patch := reflect.ValueOf(update).Elem()
+
+	var diffs []Diff
+
+	for i := 0; i < want.NumField(); i++ {
+		name := jsonName(want.Type().Field(i))
+		if slices.Contains(readOnlySettings, name) || want.Field(i).IsNil() {
+			continue
+		}
+
+		var old any
+		if have.IsValid() && !have.Field(i).IsNil() {
+			if reflect.DeepEqual(have.Field(i).Elem().Interface(), want.Field(i).Elem().Interface()) {
+				continue
+			}
+
+			old = have.Field(i).Elem().Interface()
+		}
+
+		patch.Field(i).Set(want.Field(i))
+		diffs = append(diffs, Diff{Op: Change, Path: "campaign." + name, Old: old, New: want.Field(i).Elem().Interface()})
+	}
+
+	if len(diffs) == 0 {
+		return
+	}
+
+	plan.Steps = append(plan.Steps, &Step{
+		Description: "update campaign settings",
+		order:       orderSettings,
+		Diffs:       diffs,
+		apply: func(ctx context.Context) error {
+			_, err := p.campaigns.Update(ctx, plan.CampaignID, update)
+			return err
+		},
+	})
+}
+
+func (p *Planner) planVariations(plan *Plan, desired *Spec, live *exoclick.Campaign) {
+	if desired.Variations == nil {
+		return
+	}
+
+	existing := make(map[int]exoclick.Variation)
+	if live.Variations != nil {
+		for _, v := range *live.Variations {
+			existing[v.ID] = v
+		}
+	}
+
+	wanted := make(map[int]bool)
+	for _, v := range *desired.Variations {
+		if v.ID != 0 {
+			wanted[v.ID] = true
+		}
+	}
+
+	named := make(map[string][]int)
+	for _, id := range sortedKeys(existing) {
+		if !wanted[id] {
+			named[existing[id].Name] = append(named[existing[id].Name], id)
+		}
+	}
+
+	seen := make(map[string]bool)
+
+	for _, v := range *desired.Variations {
+		v := v
+
+		if v.ID == 0 {
+			if v.Name == nil || *v.Name == "" {
+				plan.Warnings = append(plan.Warnings, "variation without idvariation or name ignored; set a name so it can be matched once created")
+				continue
+			}
+
+			name := *v.Name
+			if seen[name] {
+				plan.Warnings = append(plan.Warnings, fmt.Sprintf("duplicate variation name %q ignored", name))
+				continue
+			}
+
+			seen[name] = true
+
+			switch ids := named[name]; len(ids) {
+			case 0:
+				plan.Steps = append(plan.Steps, &Step{
+					Description: fmt.Sprintf("create variation %s", format(name)),
+					order:       orderCreate,
+					Diffs:       []Diff{{Op: Add, Path: "variations", New: name}},
+					apply: func(ctx context.Context) error {
+						created, _, err := p.campaigns.CreateVariation(ctx, plan.CampaignID, &v.VariationData)
+						if err != nil || v.Active == nil || *v.Active != 0 {
+							return err
+						}
+
+						_, err = p.campaigns.ChangeVariationStatus(ctx, plan.CampaignID, created.ID, exoclick.Pause)
+						return err
+					},
+				})
+				continue
+			case 1:
+				v.ID = ids[0]
+			default:
+				plan.Warnings = append(plan.Warnings, fmt.Sprintf("variation name %q matches variations %v; set idvariation to choose one", name, ids))
+				for _, id := range ids {
+					wanted[id] = true
+				}
+				continue
+			}
+		}
+
+		wanted[v.ID] = true
+
+		current, ok := existing[v.ID]
+		if !ok {
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("variation %d does not exist on the campaign; set id to 0 to create it", v.ID))
+			continue
+		}
+
+		if update, diffs := variationDiffs(current, v); len(diffs) > 0 {
+			plan.Steps = append(plan.Steps, &Step{
+				Description: fmt.Sprintf("update variation %d", v.ID),
+				order:       orderCreate,
+				Diffs:       diffs,
+				apply: func(ctx context.Context) error {
+					_, err := p.campaigns.UpdateVariation(ctx, plan.CampaignID, v.ID, update)
+					return err
+				},
+			})
+		}
+
+		if v.Active != nil && (current.Active != 0) != (*v.Active != 0) {
+			p.variationStatus(plan, v.ID, current.Active, *v.Active)
+		}
+	}
+
+	for _, id := range sortedKeys(existing) {
+		if !wanted[id] && existing[id].Active != 0 {
+			p.variationStatus(plan, id, existing[id].Active, 0)
+		}
+	}
+}
+
+func (p *Planner) variationStatus(plan *Plan, id int, from, to int) {
+	operation, order := exoclick.Pause, orderDeactivate
+	if to != 0 {
+		operation, order = exoclick.Play, orderActivate
+	}
+
+	plan.Steps = append(plan.Steps, &Step{
+		Description: fmt.Sprintf("%s variation %d", operation, id),
+		order:       order,
+		Diffs:       []Diff{{Op: Change, Path: fmt.Sprintf("variations[%d].active", id), Old: from, New: to}},
+		apply: func(ctx context.Context) error {
+			_, err := p.campaigns.ChangeVariationStatus(ctx, plan.CampaignID, id, operation)
+			return err
+		},
+	})
+}
+
+func variationDiffs(have exoclick.Variation, want VariationSpec) (*exoclick.VariationData, []Diff) {
+	var diffs []Diff
+
+	update := new(exoclick.VariationData)
+	patch := reflect.ValueOf(update).Elem()
+
+	h := reflect.ValueOf(have)
+	w := reflect.ValueOf(want.VariationData)
+
+	haveFields := make(map[string]reflect.Value)
+	for i := 0; i < h.NumField(); i++ {
+		haveFields[jsonName(h.Type().Field(i))] = h.Field(i)
+	}
+
+	for i := 0; i < w.NumField(); i++ {
+		if w.Field(i).IsNil() {
+			continue
+		}
+
+		name := jsonName(w.Type().Field(i))
+		value := w.Field(i).Elem().Interface()
+
+		var old any
+		if field, ok := haveFields[name]; ok {
+			if field.Kind() == reflect.Ptr {
+				if !field.IsNil() {
+					old = field.Elem().Interface()
+				}
+			} else {
+				old = field.Interface()
+			}
+		}
+
+		if reflect.DeepEqual(old, value) {
+			continue
+		}
+
+		patch.Field(i).Set(w.Field(i))
+		diffs = append(diffs, Diff{
+			Op:   Change,
+			Path: fmt.Sprintf("variations[%d].%s", want.ID, name),
+			Old:  old,
+			New:  value,
+		})
+	}
+
+	return update, diffs
+}
+
+func (p *Planner) planTargeting(plan *Plan, desired *Spec, live *exoclick.Campaign) {
+	if desired.CampaignCategories != nil {
+		var liveTargeted []int
+		if live.CampaignCategories != nil {
+			liveTargeted = categoryIDs(live.CampaignCategories.Targeted)
+		}
+
+		wantTargeted := categoryIDs(desired.CampaignCategories.Targeted)
+		added, removed := setDiff(liveTargeted, wantTargeted)
+
+		if len(added) > 0 {
+			plan.Steps = append(plan.Steps, &Step{
+				Description: "target categories",
+				order:       orderTarget,
+				Diffs:       idDiffs(Add, "categories.targeted", added),
+				apply: func(ctx context.Context) error {
+					_, err := p.campaigns.TargetCategories(ctx, plan.CampaignID, added)
+					return err
+				},
+			})
+		}
+
+		if len(removed) > 0 {
+			plan.Steps = append(plan.Steps, &Step{
+				Description: "untarget categories",
+				order:       orderUntarget,
+				Diffs:       idDiffs(Remove, "categories.targeted", removed),
+				apply: func(ctx context.Context) error {
+					_, err := p.campaigns.BlockCategories(ctx, plan.CampaignID, removed)
+					return err
+				},
+			})
+		}
+
+		if len(desired.CampaignCategories.Blocked) > 0 {
+			plan.Warnings = append(plan.Warnings, "categories.blocked is not managed; only targeted categories are applied")
+		}
+	}
+
+	if desired.CampaignZones == nil {
+		return
+	}
+
+	var liveType int
+	if live.ZoneTargeting != nil {
+		liveType = live.ZoneTargeting.Type
+	}
+
+	if desired.ZoneTargeting != nil && desired.ZoneTargeting.Type != liveType {
+		plan.Conflicts = append(plan.Conflicts, Diff{Op: Change, Path: "zone_targeting.type", Old: liveType, New: desired.ZoneTargeting.Type})
+		plan.Warnings = append(plan.Warnings, "zone targeting type differs from the live campaign and cannot be changed through the API; zones were not planned")
+		return
+	}
+
+	existing := make(map[int]exoclick.CampaignZones)
+	if live.CampaignZones != nil {
+		for _, zone := range *live.CampaignZones {
+			if zone.ZoneID != nil {
+				existing[*zone.ZoneID] = zone
+			}
+		}
+	}
+
+	wanted := make(map[int]bool)
+
+	var added, repriced []exoclick.CampaignZones
+	var addedDiffs, repricedDiffs []Diff
+
+	for _, zone := range *desired.CampaignZones {
+		if zone.ZoneID == nil {
+			plan.Warnings = append(plan.Warnings, "zone without idzone ignored")
+			continue
+		}
+
+		id := *zone.ZoneID
+		wanted[id] = true
+
+		current, ok := existing[id]
+		if !ok {
+			added = append(added, zone)
+			addedDiffs = append(addedDiffs, Diff{Op: Add, Path: fmt.Sprintf("zones[%d]", id), New: zone.Price})
+			continue
+		}
+
+		if zone.Price != nil && (current.Price == nil || *current.Price != *zone.Price) {
+			repriced = append(repriced, zone)
+			repricedDiffs = append(repricedDiffs, Diff{Op: Change, Path: fmt.Sprintf("zones[%d].price", id), Old: current.Price, New: zone.Price})
+		}
+	}
+
+	var removed []exoclick.CampaignZones
+	var removedDiffs []Diff
+
+	for _, id := range sortedKeys(existing) {
+		if !wanted[id] {
+			removed = append(removed, existing[id])
+			removedDiffs = append(removedDiffs, Diff{Op: Remove, Path: fmt.Sprintf("zones[%d]", id), Old: existing[id].Price})
+		}
+	}
+
+	if len(added) > 0 {
+		plan.Steps = append(plan.Steps, &Step{
+			Description: "target zones",
+			order:       orderTarget,
+			Diffs:       addedDiffs,
+			apply: func(ctx context.Context) error {
+				_, err := p.campaigns.TargetZones(ctx, plan.CampaignID, added)
+				return err
+			},
+		})
+	}
+
+	if len(repriced) > 0 {
+		plan.Steps = append(plan.Steps, &Step{
+			Description: "update zone prices",
+			order:       orderReprice,
+			Diffs:       repricedDiffs,
+			apply: func(ctx context.Context) error {
+				_, err := p.campaigns.UpdateZonePrices(ctx, plan.CampaignID, repriced)
+				return err
+			},
+		})
+	}
+
+	if len(removed) > 0 {
+		plan.Steps = append(plan.Steps, &Step{
+			Description: "untarget zones",
+			order:       orderUntarget,
+			Diffs:       removedDiffs,
+			apply: func(ctx context.Context) error {
+				_, err := p.campaigns.UntargetZones(ctx, plan.CampaignID, removed)
+				return err
+			},
+		})
+	}
+}
+
+func (p *Planner) planSchedule(plan *Plan, desired *Spec, live *exoclick.Campaign) {
+	if desired.Dayparting == nil {
+		return
+	}
+
+	want, _ := json.Marshal(desired.Dayparting)
+	have, _ := json.Marshal(live.Dayparting)
+
+	if string(want) == string(have) {
+		return
+	}
+
+	var old any
+	if live.Dayparting != nil {
+		old = live.Dayparting.String()
+	}
+
+	plan.Steps = append(plan.Steps, &Step{
+		Description: "update dayparting schedule",
+		order:       orderReprice,
+		Diffs:       []Diff{{Op: Change, Path: "dayparting", Old: old, New: desired.Dayparting.String()}},
+		apply: func(ctx context.Context) error {
+			_, err := p.campaigns.SetSchedule(ctx, plan.CampaignID, desired.Dayparting)
+			return err
+		},
+	})
+}
+
+func jsonName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	if name == "" {
+		return f.Name
+	}
+
+	return name
+}
+
+func format(v any) string {
+	if v == nil {
+		return "<unset>"
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return "<unset>"
+		}
+
+		v = rv.Elem().Interface()
+	}
+
+	if s, ok := v.(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+
+	return fmt.Sprint(v)
+}
+
+func categoryIDs(categories []exoclick.Category) []int {
+	var ids []int
+
+	for _, category := range categories {
+		if category.ID != nil {
+			ids = append(ids, *category.ID)
+		}
+	}
+
+	return ids
+}
+
+func setDiff(have, want []int) (added, removed []int) {
+	for _, id := range want {
+		if !slices.Contains(have, id) {
+			added = append(added, id)
+		}
+	}
+
+	for _, id := range have {
+		if !slices.Contains(want, id) {
+			removed = append(removed, id)
+		}
+	}
+
+	slices.Sort(added)
+	slices.Sort(removed)
+
+	return added, removed
+}
+
+func idDiffs(op Op, path string, ids []int) []Diff {
+	diffs := make([]Diff, len(ids))
+
+	for i, id := range ids {
+		diff := Diff{Op: op, Path: path}
+		if op == Remove {
+			diff.Old = id
+		} else {
+			diff.New = id
+		}
+
+		diffs[i] = diff
+	}
+
+	return diffs
+}
+
+func sortedKeys[V any](m map[int]V) []int {
+	keys := make([]int, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
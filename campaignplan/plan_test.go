@@ -0,0 +1,80 @@
+package campaignplan
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/adam-szerdahelyi/go-exoclick/exoclick"
+)
+
+func TestPlanMatchesCreatedVariationsByName(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /login", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"token":"test","expires_in":3600}`))
+	})
+	mux.HandleFunc("GET /campaigns/1", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"result":{"campaign":{"id":1},"variations":[
+			{"idvariation":10,"name":"banner","url":"https://example.com/a","active":1},
+			{"idvariation":11,"name":"old","url":"https://example.com/b","active":1}
+		]}}`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := exoclick.NewClient(nil, "token")
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+
+	spec, err := LoadSpec(strings.NewReader(`
+campaign:
+  id: 1
+variations:
+  - name: banner
+    url: https://example.com/a
+  - idvariation: 11
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plan, err := NewPlanner(client).Plan(context.Background(), spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !plan.Empty() {
+		t.Errorf("expected an empty plan, but got:\n%s", plan)
+	}
+
+	spec, err = LoadSpec(strings.NewReader(`
+campaign:
+  id: 1
+variations:
+  - name: banner
+    url: https://example.com/c
+  - name: new
+    url: https://example.com/d
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plan, err = NewPlanner(client).Plan(context.Background(), spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var descriptions []string
+	for _, step := range plan.Steps {
+		descriptions = append(descriptions, step.Description)
+	}
+
+	want := []string{"update variation 10", `create variation "new"`, "pause variation 11"}
+	if strings.Join(descriptions, "|") != strings.Join(want, "|") {
+		t.Errorf("expected steps %q, but got %q", want, descriptions)
+	}
+}
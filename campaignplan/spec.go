@@ -0,0 +1,64 @@
+package campaignplan
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/adam-szerdahelyi/go-exoclick/exoclick"
+	"gopkg.in/yaml.v3"
+)
+
+type Spec struct {
+	Campaign           *exoclick.CampaignData       `json:"campaign,omitempty"`
+	CampaignZones      *[]exoclick.CampaignZones    `json:"zones,omitempty"`
+	CampaignCategories *exoclick.CampaignCategories `json:"categories,omitempty"`
+	Variations         *[]VariationSpec             `json:"variations,omitempty"`
+	ZoneTargeting      *exoclick.ZoneTargeting      `json:"zone_targeting,omitempty"`
+	Dayparting         *exoclick.Schedule           `json:"dayparting,omitempty"`
+}
+
+type VariationSpec struct {
+	ID     int  `json:"idvariation,omitempty"`
+	Active *int `json:"active,omitempty"`
+
+	exoclick.VariationData
+}
+
+func LoadSpec(r io.Reader) (*Spec, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return nil, errors.New("campaign spec is empty")
+	}
+
+	if trimmed[0] != '{' {
+		var doc any
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, err
+		}
+
+		if data, err = json.Marshal(doc); err != nil {
+			return nil, err
+		}
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+
+	spec := new(Spec)
+	if err := dec.Decode(spec); err != nil {
+		return nil, err
+	}
+
+	if spec.Campaign == nil || spec.Campaign.ID == nil {
+		return nil, errors.New("campaign spec must set campaign.id")
+	}
+
+	return spec, nil
+}
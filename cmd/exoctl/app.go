@@ -0,0 +1,207 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/adam-szerdahelyi/go-exoclick/exoclick"
+)
+
+type app struct {
+	stdout     io.Writer
+	configPath string
+	profile    string
+	token      string
+	format     string
+	dryRun     bool
+
+	client   *exoclick.Client
+	timezone string
+}
+
+func (a *app) validateFormat() error {
+	switch a.format {
+	case "table", "json", "csv":
+		return nil
+	default:
+		return fmt.Errorf("invalid output format %q, expected table, json or csv", a.format)
+	}
+}
+
+func (a *app) newClient() (*exoclick.Client, error) {
+	if a.client != nil {
+		return a.client, nil
+	}
+
+	cfg, err := loadConfig(a.configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	token := a.token
+
+	p, name, err := cfg.profile(a.profile)
+	switch {
+	case err == nil:
+		if token == "" {
+			token = p.APIToken
+		}
+
+		a.timezone = p.Timezone
+	case a.profile != "" || token == "":
+		return nil, err
+	}
+
+	if token == "" {
+		return nil, fmt.Errorf("profile %q has no API token", name)
+	}
+
+	client := exoclick.NewClient(nil, token)
+	client.SetDryRun(a.dryRun)
+
+	if p != nil && p.BaseURL != "" {
+		baseURL, err := url.Parse(strings.TrimSuffix(p.BaseURL, "/") + "/")
+		if err != nil {
+			return nil, fmt.Errorf("invalid base URL %q: %w", p.BaseURL, err)
+		}
+
+		client.BaseURL = baseURL
+	}
+
+	a.client = client
+
+	return client, nil
+}
+
+func (a *app) print(t *table, v any) error {
+	switch a.format {
+	case "json":
+		enc := json.NewEncoder(a.stdout)
+		enc.SetIndent("", "  ")
+
+		return enc.Encode(v)
+	case "csv":
+		w := csv.NewWriter(a.stdout)
+		if err := w.Write(t.header); err != nil {
+			return err
+		}
+
+		if err := w.WriteAll(t.rows); err != nil {
+			return err
+		}
+
+		return w.Error()
+	default:
+		w := tabwriter.NewWriter(a.stdout, 0, 0, 2, ' ', 0)
+
+		fmt.Fprintln(w, strings.ToUpper(strings.Join(t.header, "\t")))
+		for _, row := range t.rows {
+			fmt.Fprintln(w, strings.Join(row, "\t"))
+		}
+
+		return w.Flush()
+	}
+}
+
+type table struct {
+	header []string
+	rows   [][]string
+}
+
+func (t *table) append(values ...any) {
+	row := make([]string, len(values))
+	for i, v := range values {
+		row[i] = cell(v)
+	}
+
+	t.rows = append(t.rows, row)
+}
+
+func cell(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case *string:
+		if v == nil {
+			return ""
+		}
+
+		return *v
+	case int:
+		return strconv.Itoa(v)
+	case *int:
+		if v == nil {
+			return ""
+		}
+
+		return strconv.Itoa(*v)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case *float64:
+		if v == nil {
+			return ""
+		}
+
+		return strconv.FormatFloat(*v, 'f', -1, 64)
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+
+		return v.Format(time.DateOnly)
+	case *exoclick.CustomDate:
+		if v == nil {
+			return ""
+		}
+
+		return v.Format(time.DateOnly)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func parseFlags(name string, args []string) error {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+
+	return nil
+}
+
+func parseIDs(args []string) ([]int, error) {
+	if len(args) == 0 {
+		return nil, errors.New("at least one id is required")
+	}
+
+	ids := make([]int, 0, len(args))
+	for _, arg := range args {
+		for _, part := range strings.Split(arg, ",") {
+			id, err := strconv.Atoi(strings.TrimSpace(part))
+			if err != nil {
+				return nil, fmt.Errorf("invalid id %q", part)
+			}
+
+			ids = append(ids, id)
+		}
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"strconv"
+
+	"github.com/adam-szerdahelyi/go-exoclick/exoclick"
+)
+
+func campaignsList(ctx context.Context, app *app, args []string) error {
+	fs := flag.NewFlagSet("campaigns list", flag.ContinueOnError)
+	status := fs.Int("status", 0, "filter by status")
+	search := fs.String("search", "", "filter by name")
+	orderBy := fs.String("order-by", "", "sort order, e.g. d:id")
+	limit := fs.Int("limit", 0, "maximum number of campaigns")
+	offset := fs.Int("offset", 0, "number of campaigns to skip")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	client, err := app.newClient()
+	if err != nil {
+		return err
+	}
+
+	campaigns, _, err := client.Campaigns.List(ctx, &exoclick.CampaignListOptions{
+		Status:       *status,
+		CustomSearch: *search,
+		OrderBy:      *orderBy,
+		ListOptions:  exoclick.ListOptions{Limit: *limit, Offset: *offset},
+	})
+	if err != nil {
+		return err
+	}
+
+	t := &table{header: []string{"id", "name", "status", "price", "daily_budget", "created"}}
+	for _, c := range campaigns {
+		t.append(c.ID, c.Name, c.Status, c.Price, c.DailyBudget, c.DateCreated)
+	}
+
+	return app.print(t, campaigns)
+}
+
+func campaignsGet(ctx context.Context, app *app, args []string) error {
+	fs := flag.NewFlagSet("campaigns get", flag.ContinueOnError)
+	detailed := fs.Bool("detailed", false, "include zones, categories and variations")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() != 1 {
+		return errors.New("usage: exoctl campaigns get [flags] <campaign-id>")
+	}
+
+	id, err := strconv.Atoi(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("invalid campaign id %q", fs.Arg(0))
+	}
+
+	client, err := app.newClient()
+	if err != nil {
+		return err
+	}
+
+	campaign, _, err := client.Campaigns.Get(ctx, id, *detailed)
+	if err != nil {
+		return err
+	}
+
+	t := &table{header: []string{"id", "name", "status", "price", "daily_budget", "zones", "variations"}}
+	if c := campaign.Campaign; c != nil {
+		var zones, variations int
+		if campaign.CampaignZones != nil {
+			zones = len(*campaign.CampaignZones)
+		}
+
+		if campaign.Variations != nil {
+			variations = len(*campaign.Variations)
+		}
+
+		t.append(c.ID, c.Name, c.Status, c.Price, c.DailyBudget, zones, variations)
+	}
+
+	return app.print(t, campaign)
+}
+
+func campaignsPause(ctx context.Context, app *app, args []string) error {
+	return changeCampaignStatus(ctx, app, args, exoclick.Pause)
+}
+
+func campaignsPlay(ctx context.Context, app *app, args []string) error {
+	return changeCampaignStatus(ctx, app, args, exoclick.Play)
+}
+
+func changeCampaignStatus(ctx context.Context, app *app, args []string, op exoclick.Operation) error {
+	fs := flag.NewFlagSet("campaigns "+string(op), flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	ids, err := parseIDs(fs.Args())
+	if err != nil {
+		return err
+	}
+
+	client, err := app.newClient()
+	if err != nil {
+		return err
+	}
+
+	if _, err := client.Campaigns.ChangeCampaignStatus(ctx, op, ids...); err != nil {
+		return err
+	}
+
+	t := &table{header: []string{"campaign_id", "operation"}}
+	for _, id := range ids {
+		t.append(id, string(op))
+	}
+
+	return app.print(t, map[string]any{"campaign_ids": ids, "operation": op})
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+type profile struct {
+	APIToken string `yaml:"api_token"`
+	BaseURL  string `yaml:"base_url,omitempty"`
+	Timezone string `yaml:"timezone,omitempty"`
+}
+
+type config struct {
+	DefaultProfile string              `yaml:"default_profile,omitempty"`
+	Profiles       map[string]*profile `yaml:"profiles"`
+}
+
+func defaultConfigPath() string {
+	if path := os.Getenv("EXOCTL_CONFIG"); path != "" {
+		return path
+	}
+
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "exoctl.yaml"
+	}
+
+	return filepath.Join(dir, "exoctl", "config.yaml")
+}
+
+func loadConfig(path string) (*config, error) {
+	cfg := &config{Profiles: map[string]*profile{}}
+
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := yaml.Unmarshal(b, cfg); err != nil {
+		return nil, fmt.Errorf("decoding config %s: %w", path, err)
+	}
+
+	if cfg.Profiles == nil {
+		cfg.Profiles = map[string]*profile{}
+	}
+
+	return cfg, nil
+}
+
+func (c *config) save(path string) error {
+	b, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, b, 0o600)
+}
+
+func (c *config) profile(name string) (*profile, string, error) {
+	if name == "" {
+		name = c.DefaultProfile
+	}
+
+	if name == "" {
+		name = "default"
+	}
+
+	p, ok := c.Profiles[name]
+	if !ok {
+		return nil, name, fmt.Errorf("profile %q not found in config", name)
+	}
+
+	return p, name, nil
+}
+
+func configList(_ context.Context, app *app, args []string) error {
+	if err := parseFlags("config list", args); err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig(app.configPath)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(cfg.Profiles))
+	for name := range cfg.Profiles {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	masked := &config{DefaultProfile: cfg.DefaultProfile, Profiles: make(map[string]*profile, len(cfg.Profiles))}
+
+	t := &table{header: []string{"profile", "default", "base_url", "timezone", "token"}}
+	for _, name := range names {
+		p := cfg.Profiles[name]
+		masked.Profiles[name] = &profile{APIToken: mask(p.APIToken), BaseURL: p.BaseURL, Timezone: p.Timezone}
+
+		def := ""
+		if name == cfg.DefaultProfile {
+			def = "*"
+		}
+
+		t.append(name, def, p.BaseURL, p.Timezone, mask(p.APIToken))
+	}
+
+	return app.print(t, masked)
+}
+
+func configSet(_ context.Context, app *app, args []string) error {
+	fs := flag.NewFlagSet("config set", flag.ContinueOnError)
+	token := fs.String("token", "", "API token")
+	baseURL := fs.String("base-url", "", "API base URL")
+	timezone := fs.String("timezone", "", "default statistics timezone")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() != 1 {
+		return errors.New("usage: exoctl config set [flags] <profile>")
+	}
+
+	cfg, err := loadConfig(app.configPath)
+	if err != nil {
+		return err
+	}
+
+	name := fs.Arg(0)
+
+	p, ok := cfg.Profiles[name]
+	if !ok {
+		p = &profile{}
+		cfg.Profiles[name] = p
+	}
+
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "token":
+			p.APIToken = *token
+		case "base-url":
+			p.BaseURL = *baseURL
+		case "timezone":
+			p.Timezone = *timezone
+		}
+	})
+
+	if cfg.DefaultProfile == "" {
+		cfg.DefaultProfile = name
+	}
+
+	return cfg.save(app.configPath)
+}
+
+func configUse(_ context.Context, app *app, args []string) error {
+	fs := flag.NewFlagSet("config use", flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() != 1 {
+		return errors.New("usage: exoctl config use <profile>")
+	}
+
+	cfg, err := loadConfig(app.configPath)
+	if err != nil {
+		return err
+	}
+
+	if _, _, err := cfg.profile(fs.Arg(0)); err != nil {
+		return err
+	}
+
+	cfg.DefaultProfile = fs.Arg(0)
+
+	return cfg.save(app.configPath)
+}
+
+func mask(token string) string {
+	if len(token) <= 4 {
+		return "****"
+	}
+
+	return "****" + token[len(token)-4:]
+}
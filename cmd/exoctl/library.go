@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+
+	"github.com/adam-szerdahelyi/go-exoclick/exoclick"
+)
+
+func categoriesList(ctx context.Context, app *app, args []string) error {
+	fs := flag.NewFlagSet("categories list", flag.ContinueOnError)
+	orderBy := fs.String("order-by", "", "sort order, e.g. a:id")
+	limit := fs.Int("limit", 0, "maximum number of categories")
+	offset := fs.Int("offset", 0, "number of categories to skip")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	client, err := app.newClient()
+	if err != nil {
+		return err
+	}
+
+	categories, _, err := client.Category.List(ctx, &exoclick.CategoryListOptions{
+		OrderBy:     *orderBy,
+		ListOptions: exoclick.ListOptions{Limit: *limit, Offset: *offset},
+	})
+	if err != nil {
+		return err
+	}
+
+	t := &table{header: []string{"id", "name", "long_name", "parent", "enabled"}}
+	for _, c := range categories {
+		t.append(c.ID, c.Name, c.LongName, c.Parent, c.Enabled)
+	}
+
+	return app.print(t, categories)
+}
+
+func filesList(ctx context.Context, app *app, args []string) error {
+	fs := flag.NewFlagSet("files list", flag.ContinueOnError)
+	fileType := fs.String("type", string(exoclick.FileTypeImage), "file type: image, video or video_banner")
+	archived := fs.Bool("archived", false, "include archived files")
+	orderBy := fs.String("order-by", "", "sort order, e.g. a:id")
+	limit := fs.Int("limit", 0, "maximum number of files")
+	offset := fs.Int("offset", 0, "number of files to skip")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	client, err := app.newClient()
+	if err != nil {
+		return err
+	}
+
+	files, _, err := client.File.List(ctx, &exoclick.FileListOptions{
+		Type:         exoclick.FileType(*fileType),
+		ShowArchived: *archived,
+		OrderBy:      *orderBy,
+		ListOptions:  exoclick.ListOptions{Limit: *limit, Offset: *offset},
+	})
+	if err != nil {
+		return err
+	}
+
+	t := &table{header: []string{"id", "type", "name", "width", "height", "size", "url"}}
+	for _, f := range files {
+		t.append(f.ID, string(f.Type), f.FileName, f.Width, f.Height, f.FileSizeOriginal, f.URL)
+	}
+
+	return app.print(t, files)
+}
+
+func filesUpload(ctx context.Context, app *app, args []string) error {
+	fs := flag.NewFlagSet("files upload", flag.ContinueOnError)
+	fileType := fs.String("type", string(exoclick.FileTypeImage), "file type: image, video or video_banner")
+	name := fs.String("name", "", "file name, defaults to the base name of the path")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() != 1 {
+		return errors.New("usage: exoctl files upload [flags] <path>")
+	}
+
+	path := fs.Arg(0)
+
+	if *name == "" {
+		*name = filepath.Base(path)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	client, err := app.newClient()
+	if err != nil {
+		return err
+	}
+
+	file, _, err := client.File.Upload(ctx, f, &exoclick.FileUploadOptions{
+		Type:     exoclick.FileType(*fileType),
+		FileName: *name,
+	})
+	if err != nil {
+		return err
+	}
+
+	t := &table{header: []string{"id", "type", "name", "url"}}
+	t.append(file.ID, string(file.Type), file.FileName, file.URL)
+
+	return app.print(t, file)
+}
+
+func marketplaceList(ctx context.Context, app *app, args []string) error {
+	fs := flag.NewFlagSet("marketplace list", flag.ContinueOnError)
+	orderBy := fs.String("order-by", "", "sort order, e.g. d:daily_impressions")
+	limit := fs.Int("limit", 50, "maximum number of zones")
+	offset := fs.Int("offset", 0, "number of zones to skip")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	client, err := app.newClient()
+	if err != nil {
+		return err
+	}
+
+	zones, _, err := client.Marketplace.List(ctx, &exoclick.MarketplaceListOptions{
+		OrderBy:     *orderBy,
+		ListOptions: exoclick.ListOptions{Limit: *limit, Offset: *offset},
+	})
+	if err != nil {
+		return err
+	}
+
+	t := &table{header: []string{"zone_id", "site_id", "site", "category", "size", "daily_impressions", "daily_clicks"}}
+	for _, z := range zones {
+		t.append(z.ZoneID, z.SiteID, z.SiteHostname, z.CategoryName, z.Size, z.DailyImpressions, z.DailyClicks)
+	}
+
+	return app.print(t, zones)
+}
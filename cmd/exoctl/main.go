@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+)
+
+const usage = `exoctl is a command-line client for the ExoClick API.
+
+Usage:
+  exoctl [global flags] <command> <subcommand> [flags]
+
+Commands:
+  campaigns    list | get | pause | play
+  variations   list | pause | play
+  categories   list
+  files        list | upload
+  marketplace  list
+  statistics   get
+  config       list | set | use
+
+Global flags:
+`
+
+type command func(ctx context.Context, app *app, args []string) error
+
+var commands = map[string]map[string]command{
+	"campaigns": {
+		"list":  campaignsList,
+		"get":   campaignsGet,
+		"pause": campaignsPause,
+		"play":  campaignsPlay,
+	},
+	"variations": {
+		"list":  variationsList,
+		"pause": variationsPause,
+		"play":  variationsPlay,
+	},
+	"categories": {
+		"list": categoriesList,
+	},
+	"files": {
+		"list":   filesList,
+		"upload": filesUpload,
+	},
+	"marketplace": {
+		"list": marketplaceList,
+	},
+	"statistics": {
+		"get": statisticsGet,
+	},
+	"config": {
+		"list": configList,
+		"set":  configSet,
+		"use":  configUse,
+	},
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := run(ctx, os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, "exoctl:", err)
+		}
+
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	app := &app{stdout: stdout}
+
+	fs := flag.NewFlagSet("exoctl", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.StringVar(&app.configPath, "config", defaultConfigPath(), "path to the configuration file")
+	fs.StringVar(&app.profile, "profile", os.Getenv("EXOCTL_PROFILE"), "configuration profile to use")
+	fs.StringVar(&app.token, "token", os.Getenv("EXOCLICK_API_TOKEN"), "API token, overrides the profile")
+	fs.StringVar(&app.format, "output", "table", "output format: table, json or csv")
+	fs.BoolVar(&app.dryRun, "dry-run", false, "print mutating requests instead of sending them")
+	fs.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if err := app.validateFormat(); err != nil {
+		return err
+	}
+
+	args = fs.Args()
+	if len(args) < 2 {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+
+	subcommands, ok := commands[args[0]]
+	if !ok {
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+
+	cmd, ok := subcommands[args[1]]
+	if !ok {
+		names := make([]string, 0, len(subcommands))
+		for name := range subcommands {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		return fmt.Errorf("unknown subcommand %q for %s, expected one of: %s", args[1], args[0], strings.Join(names, ", "))
+	}
+
+	err := cmd(ctx, app, args[2:])
+
+	if app.client != nil && app.dryRun {
+		for _, req := range app.client.DryRunPlan() {
+			fmt.Fprintln(stderr, req)
+		}
+	}
+
+	return err
+}
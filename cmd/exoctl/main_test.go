@@ -0,0 +1,206 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func newTestServer(t *testing.T, mux *http.ServeMux) *httptest.Server {
+	t.Helper()
+
+	mux.HandleFunc("POST /login", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"token":"test","expires_in":3600}`))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func writeTestConfig(t *testing.T, cfg *config) string {
+	t.Helper()
+
+	t.Setenv("EXOCTL_PROFILE", "")
+	t.Setenv("EXOCLICK_API_TOKEN", "")
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := cfg.save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func runTest(t *testing.T, args ...string) (string, string, error) {
+	t.Helper()
+
+	var stdout, stderr bytes.Buffer
+	err := run(context.Background(), args, &stdout, &stderr)
+
+	return stdout.String(), stderr.String(), err
+}
+
+func TestConfigListMasksTokenInJSON(t *testing.T) {
+	path := writeTestConfig(t, &config{
+		DefaultProfile: "default",
+		Profiles: map[string]*profile{
+			"default": {APIToken: "secret-token-1234", Timezone: "Europe/Budapest"},
+		},
+	})
+
+	stdout, _, err := runTest(t, "-config", path, "-output", "json", "config", "list")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(stdout, "secret-token") {
+		t.Fatalf("expected the token to be masked, but got %s", stdout)
+	}
+
+	var got config
+	if err := json.Unmarshal([]byte(stdout), &got); err != nil {
+		t.Fatal(err)
+	}
+
+	if p := got.Profiles["default"]; p == nil || p.APIToken != "****1234" {
+		t.Errorf("expected masked token ****1234, but got %+v", p)
+	}
+}
+
+func TestConfigSetAndUse(t *testing.T) {
+	path := writeTestConfig(t, &config{})
+
+	if _, _, err := runTest(t, "-config", path, "config", "set", "-token", "first-token", "first"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := runTest(t, "-config", path, "config", "set", "-token", "second-token", "-timezone", "UTC", "second"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := runTest(t, "-config", path, "config", "use", "missing"); err == nil {
+		t.Error("expected an error when using a missing profile")
+	}
+
+	if _, _, err := runTest(t, "-config", path, "config", "use", "second"); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := loadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cfg.DefaultProfile != "second" {
+		t.Errorf("expected default profile second, but got %q", cfg.DefaultProfile)
+	}
+
+	if p := cfg.Profiles["first"]; p == nil || p.APIToken != "first-token" {
+		t.Errorf("expected the first profile to keep its token, but got %+v", p)
+	}
+}
+
+func TestCampaignsList(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /campaigns", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("status"); got != "1" {
+			t.Errorf("expected status 1, but got %q", got)
+		}
+
+		w.Write([]byte(`{"result":[{"id":7,"name":"Spring sale","status":1}]}`))
+	})
+
+	server := newTestServer(t, mux)
+	path := writeTestConfig(t, &config{
+		Profiles: map[string]*profile{"default": {APIToken: "token", BaseURL: server.URL}},
+	})
+
+	stdout, _, err := runTest(t, "-config", path, "-output", "csv", "campaigns", "list", "-status", "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "id,name,status,price,daily_budget,created\n7,Spring sale,1,,,\n"
+	if stdout != want {
+		t.Errorf("expected %q, but got %q", want, stdout)
+	}
+}
+
+func TestCampaignsPauseDryRun(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("PUT /campaigns/pause", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("expected no request to be sent in dry-run mode")
+	})
+
+	server := newTestServer(t, mux)
+	path := writeTestConfig(t, &config{
+		Profiles: map[string]*profile{"default": {APIToken: "token", BaseURL: server.URL}},
+	})
+
+	_, stderr, err := runTest(t, "-config", path, "-dry-run", "campaigns", "pause", "7,8")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(stderr, "PUT "+server.URL+"/campaigns/pause") || !strings.Contains(stderr, `"campaign_ids":[7,8]`) {
+		t.Errorf("expected the planned request on stderr, but got %q", stderr)
+	}
+}
+
+func TestStatisticsGetUsesReportTimezone(t *testing.T) {
+	for _, timezone := range []string{"Pacific/Kiritimati", "Etc/GMT+12"} {
+		t.Run(timezone, func(t *testing.T) {
+			var body struct {
+				Filter struct {
+					DateFrom string `json:"date_from"`
+					DateTo   string `json:"date_to"`
+				} `json:"filter"`
+			}
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST /statistics/a/global", func(w http.ResponseWriter, r *http.Request) {
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					t.Error(err)
+				}
+
+				w.Write([]byte(`{"result":[],"resultSize":0}`))
+			})
+
+			server := newTestServer(t, mux)
+			path := writeTestConfig(t, &config{
+				Profiles: map[string]*profile{"default": {APIToken: "token", BaseURL: server.URL, Timezone: timezone}},
+			})
+
+			loc, err := time.LoadLocation(timezone)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			today := time.Now().In(loc).Format(time.DateOnly)
+
+			if _, _, err := runTest(t, "-config", path, "statistics", "get", "-from", today); err != nil {
+				t.Fatal(err)
+			}
+
+			if body.Filter.DateFrom != today || body.Filter.DateTo != today {
+				t.Errorf("expected %s to %s, but got %s to %s", today, today, body.Filter.DateFrom, body.Filter.DateTo)
+			}
+		})
+	}
+}
+
+func TestUnknownSubcommand(t *testing.T) {
+	_, _, err := runTest(t, "-config", filepath.Join(t.TempDir(), "missing.yaml"), "campaigns", "delete")
+	if err == nil || !strings.Contains(err.Error(), "expected one of: get, list, pause, play") {
+		t.Errorf("expected an unknown subcommand error, but got %v", err)
+	}
+}
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/adam-szerdahelyi/go-exoclick/exoclick"
+)
+
+func statisticsGet(ctx context.Context, app *app, args []string) error {
+	fs := flag.NewFlagSet("statistics get", flag.ContinueOnError)
+	from := fs.String("from", "", "start date (YYYY-MM-DD)")
+	to := fs.String("to", "", "end date (YYYY-MM-DD), defaults to today")
+	last := fs.Int("last", 0, "number of days up to and including today (default 7 when -from is not set)")
+	groupBy := fs.String("group-by", "date", "comma-separated fields to group by")
+	metrics := fs.String("metrics", "", "comma-separated metrics (default all)")
+	orderBy := fs.String("order-by", "", "comma-separated field:asc|desc pairs")
+	timezone := fs.String("timezone", "", "IANA timezone, defaults to the profile timezone")
+	campaign := fs.Int("campaign", 0, "filter by campaign id")
+	variation := fs.Int("variation", 0, "filter by variation id")
+	site := fs.Int("site", 0, "filter by site id")
+	zone := fs.Int("zone", 0, "filter by zone id")
+	category := fs.Int("category", 0, "filter by category id")
+	hours := fs.String("hours", "", "comma-separated hours of day to include")
+	excludeDeleted := fs.Bool("exclude-deleted", false, "exclude deleted campaigns")
+	detailed := fs.Bool("detailed", false, "include site and zone names")
+	limit := fs.Int("limit", 0, "maximum number of rows")
+	offset := fs.Int("offset", 0, "number of rows to skip")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+
+	client, err := app.newClient()
+	if err != nil {
+		return err
+	}
+
+	b := exoclick.NewReport()
+
+	if *timezone == "" {
+		*timezone = app.timezone
+	}
+
+	loc := time.UTC
+
+	if *timezone != "" {
+		if loc, err = time.LoadLocation(*timezone); err != nil {
+			return fmt.Errorf("invalid timezone %q: %w", *timezone, err)
+		}
+
+		b.Timezone(loc)
+	}
+
+	switch {
+	case *from != "" && *last != 0:
+		return fmt.Errorf("-from and -last cannot be combined")
+	case *from != "":
+		start, err := time.ParseInLocation(time.DateOnly, *from, loc)
+		if err != nil {
+			return fmt.Errorf("invalid -from date %q", *from)
+		}
+
+		end := time.Now().In(loc)
+		if *to != "" {
+			if end, err = time.ParseInLocation(time.DateOnly, *to, loc); err != nil {
+				return fmt.Errorf("invalid -to date %q", *to)
+			}
+		}
+
+		b.Between(start, end)
+	default:
+		if *to != "" {
+			return fmt.Errorf("-to requires -from")
+		}
+
+		if *last == 0 {
+			*last = 7
+		}
+
+		b.Last(time.Duration(*last) * exoclick.Day)
+	}
+
+	b.GroupBy(fields(*groupBy)...)
+
+	if *metrics == "" {
+		b.Metrics(exoclick.MetricFields...)
+	} else {
+		b.Metrics(fields(*metrics)...)
+	}
+
+	for _, spec := range split(*orderBy) {
+		field, order, _ := strings.Cut(spec, ":")
+		if order == "" {
+			order = string(exoclick.Asc)
+		}
+
+		b.OrderBy(exoclick.StatisticsField(field), exoclick.OrderType(order))
+	}
+
+	filters := []struct {
+		value int
+		set   func(int) *exoclick.ReportBuilder
+	}{
+		{*campaign, b.Campaign},
+		{*variation, b.Variation},
+		{*site, b.Site},
+		{*zone, b.Zone},
+		{*category, b.Category},
+	}
+
+	for _, f := range filters {
+		if f.value != 0 {
+			f.set(f.value)
+		}
+	}
+
+	if *hours != "" {
+		var hs []int
+		for _, h := range split(*hours) {
+			hour, err := strconv.Atoi(h)
+			if err != nil {
+				return fmt.Errorf("invalid hour %q", h)
+			}
+
+			hs = append(hs, hour)
+		}
+
+		b.Hours(hs...)
+	}
+
+	if *excludeDeleted {
+		b.ExcludeDeleted()
+	}
+
+	if *detailed {
+		b.Detailed()
+	}
+
+	if *limit != 0 {
+		b.Limit(*limit)
+	}
+
+	if *offset != 0 {
+		b.Offset(*offset)
+	}
+
+	opts, err := b.Build()
+	if err != nil {
+		return err
+	}
+
+	report, _, err := client.Statistics.GetStatistics(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	t := &table{}
+	for _, field := range opts.OutputCsvFields {
+		t.header = append(t.header, string(field))
+	}
+
+	for _, row := range report.Rows {
+		values := make([]any, len(opts.OutputCsvFields))
+		for i, field := range opts.OutputCsvFields {
+			if v, ok := row.Dimension(field); ok {
+				values[i] = v
+			} else if v, ok := row.Metric(field); ok {
+				values[i] = v
+			}
+		}
+
+		t.append(values...)
+	}
+
+	return app.print(t, report)
+}
+
+func split(s string) []string {
+	var parts []string
+
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+
+	return parts
+}
+
+func fields(s string) []exoclick.StatisticsField {
+	var fields []exoclick.StatisticsField
+
+	for _, part := range split(s) {
+		fields = append(fields, exoclick.StatisticsField(part))
+	}
+
+	return fields
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"strconv"
+
+	"github.com/adam-szerdahelyi/go-exoclick/exoclick"
+)
+
+func variationsList(ctx context.Context, app *app, args []string) error {
+	fs := flag.NewFlagSet("variations list", flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() != 1 {
+		return errors.New("usage: exoctl variations list <campaign-id>")
+	}
+
+	campaignID, err := strconv.Atoi(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("invalid campaign id %q", fs.Arg(0))
+	}
+
+	client, err := app.newClient()
+	if err != nil {
+		return err
+	}
+
+	campaign, _, err := client.Campaigns.Get(ctx, campaignID, true)
+	if err != nil {
+		return err
+	}
+
+	variations := []exoclick.Variation{}
+	if campaign.Variations != nil {
+		variations = *campaign.Variations
+	}
+
+	t := &table{header: []string{"id", "name", "status", "active", "url"}}
+	for _, v := range variations {
+		t.append(v.ID, v.Name, v.Status, v.Active, v.Url)
+	}
+
+	return app.print(t, variations)
+}
+
+func variationsPause(ctx context.Context, app *app, args []string) error {
+	return changeVariationStatus(ctx, app, args, exoclick.Pause)
+}
+
+func variationsPlay(ctx context.Context, app *app, args []string) error {
+	return changeVariationStatus(ctx, app, args, exoclick.Play)
+}
+
+func changeVariationStatus(ctx context.Context, app *app, args []string, op exoclick.Operation) error {
+	fs := flag.NewFlagSet("variations "+string(op), flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() < 2 {
+		return fmt.Errorf("usage: exoctl variations %s <campaign-id> <variation-id>...", op)
+	}
+
+	campaignID, err := strconv.Atoi(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("invalid campaign id %q", fs.Arg(0))
+	}
+
+	ids, err := parseIDs(fs.Args()[1:])
+	if err != nil {
+		return err
+	}
+
+	client, err := app.newClient()
+	if err != nil {
+		return err
+	}
+
+	t := &table{header: []string{"campaign_id", "variation_id", "operation"}}
+	for _, id := range ids {
+		if _, err := client.Campaigns.ChangeVariationStatus(ctx, campaignID, id, op); err != nil {
+			return fmt.Errorf("variation %d: %w", id, err)
+		}
+
+		t.append(campaignID, id, string(op))
+	}
+
+	return app.print(t, map[string]any{"campaign_id": campaignID, "variation_ids": ids, "operation": op})
+}
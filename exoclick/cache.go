@@ -0,0 +1,247 @@
+package exoclick
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	defaultFinalizedAfter = 3
+	defaultRecentTTL      = 5 * time.Minute
+
+	headerFromCache = "X-From-Cache"
+)
+
+type CacheBackend interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, value []byte, ttl time.Duration)
+}
+
+type StatisticsCache struct {
+	Backend        CacheBackend
+	FinalizedAfter int
+	RecentTTL      time.Duration
+}
+
+func NewStatisticsCache(backend CacheBackend) *StatisticsCache {
+	return &StatisticsCache{
+		Backend:        backend,
+		FinalizedAfter: defaultFinalizedAfter,
+		RecentTTL:      defaultRecentTTL,
+	}
+}
+
+func (c *StatisticsCache) ttl(q reportQuery) (time.Duration, bool) {
+	loc := time.UTC
+	if q.Timezone != nil && q.Timezone.Location != nil {
+		loc = q.Timezone.Location
+	}
+
+	now := time.Now().In(loc)
+	finalized := time.Date(now.Year(), now.Month(), now.Day()-c.FinalizedAfter, 0, 0, 0, 0, time.UTC)
+	to := q.DateTo.Time
+	to = time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+
+	if !to.IsZero() && to.Before(finalized) {
+		return 0, true
+	}
+
+	return c.RecentTTL, c.RecentTTL > 0
+}
+
+func statisticsCacheKey(apiToken string, req *http.Request, opts any) (string, error) {
+	body, err := json.Marshal(opts)
+	if err != nil {
+		return "", err
+	}
+
+	account := sha256.Sum256([]byte(apiToken))
+
+	h := sha256.New()
+	h.Write(account[:])
+	io.WriteString(h, req.Method+" "+req.URL.String()+"\n")
+	io.WriteString(h, req.Header.Get("Accept")+"\n")
+	h.Write(body)
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func (s *StatisticsService) do(ctx context.Context, req *http.Request, opts any, q reportQuery) (*http.Response, error) {
+	cache := s.client.StatisticsCache
+	if cache == nil || cache.Backend == nil {
+		return s.client.BareDo(ctx, req)
+	}
+
+	key, err := statisticsCacheKey(s.client.apiToken, req, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if data, ok := cache.Backend.Get(key); ok {
+		resp := &http.Response{
+			Status:     http.StatusText(http.StatusOK),
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       io.NopCloser(bytes.NewReader(data)),
+			Request:    req,
+		}
+		resp.Header.Set(headerFromCache, "1")
+
+		return resp, nil
+	}
+
+	resp, err := s.client.BareDo(ctx, req)
+	if err != nil {
+		return resp, err
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return resp, err
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+
+	if ttl, ok := cache.ttl(q); ok {
+		cache.Backend.Set(key, data, ttl)
+	}
+
+	return resp, nil
+}
+
+type lruEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+type LRUCache struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[string]*list.Element
+	order    *list.List
+}
+
+func NewLRUCache(capacity int) *LRUCache {
+	return &LRUCache{
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+func (c *LRUCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := el.Value.(*lruEntry)
+	if !entry.expires.IsZero() && time.Now().After(entry.expires) {
+		c.order.Remove(el)
+		delete(c.entries, key)
+		return nil, false
+	}
+
+	c.order.MoveToFront(el)
+
+	return entry.value, true
+}
+
+func (c *LRUCache) Set(key string, value []byte, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var expires time.Time
+	if ttl > 0 {
+		expires = time.Now().Add(ttl)
+	}
+
+	if el, ok := c.entries[key]; ok {
+		el.Value = &lruEntry{key: key, value: value, expires: expires}
+		c.order.MoveToFront(el)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&lruEntry{key: key, value: value, expires: expires})
+
+	for c.capacity > 0 && c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry).key)
+	}
+}
+
+type DiskCache struct {
+	dir string
+}
+
+func NewDiskCache(dir string) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	return &DiskCache{dir: dir}, nil
+}
+
+func (c *DiskCache) path(key string) string {
+	return filepath.Join(c.dir, key+".cache")
+}
+
+func (c *DiskCache) Get(key string) ([]byte, bool) {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil || len(data) < 8 {
+		return nil, false
+	}
+
+	if expires := int64(binary.BigEndian.Uint64(data[:8])); expires != 0 && time.Now().UnixNano() > expires {
+		os.Remove(c.path(key))
+		return nil, false
+	}
+
+	return data[8:], true
+}
+
+func (c *DiskCache) Set(key string, value []byte, ttl time.Duration) {
+	var expires int64
+	if ttl > 0 {
+		expires = time.Now().Add(ttl).UnixNano()
+	}
+
+	f, err := os.CreateTemp(c.dir, key+".*.tmp")
+	if err != nil {
+		return
+	}
+
+	data := binary.BigEndian.AppendUint64(nil, uint64(expires))
+	data = append(data, value...)
+
+	_, err = f.Write(data)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		os.Remove(f.Name())
+		return
+	}
+
+	if err := os.Rename(f.Name(), c.path(key)); err != nil {
+		os.Remove(f.Name())
+	}
+}
@@ -0,0 +1,38 @@
+package exoclick
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestStatisticsCacheKeyIncludesAccount(t *testing.T) {
+	req, err := http.NewRequest(http.MethodPost, "https://api.exoclick.com/v2/statistics/a/global", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opts := &StatisticsOptions{GroupBy: []StatisticsField{Date}}
+
+	a, err := statisticsCacheKey("token-a", req, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := statisticsCacheKey("token-b", req, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if a == b {
+		t.Error("expected different cache keys for different accounts")
+	}
+
+	again, err := statisticsCacheKey("token-a", req, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if a != again {
+		t.Error("expected the same cache key for the same account and request")
+	}
+}
@@ -15,6 +15,7 @@ type Campaign struct {
 	CampaignCategories *CampaignCategories `json:"categories,omitempty"`
 	Variations         *[]Variation        `json:"variations,omitempty"`
 	ZoneTargeting      *ZoneTargeting      `json:"zone_targeting,omitempty"`
+	Dayparting         *Schedule           `json:"dayparting,omitempty"`
 }
 
 func (c Campaign) String() string {
@@ -29,6 +30,61 @@ type CampaignData struct {
 	PricingModel *int          `json:"pricing_model,omitempty"`
 	Price        *float64      `json:"price,omitempty"`
 	DateCreated  *CustomDate   `json:"date_created,omitempty"`
+
+	DailyBudget        *float64      `json:"daily_budget,omitempty"`
+	TotalBudget        *float64      `json:"total_budget,omitempty"`
+	Delivery           *DeliveryType `json:"delivery,omitempty"`
+	FrequencyCap       *int          `json:"frequency_cap,omitempty"`
+	FrequencyCapExpire *int          `json:"frequency_cap_expire,omitempty"`
+}
+
+type DeliveryType string
+
+const (
+	EvenDelivery DeliveryType = "even"
+	FastDelivery DeliveryType = "fast"
+)
+
+type CampaignBudget struct {
+	DailyBudget        *float64
+	TotalBudget        *float64
+	Delivery           *DeliveryType
+	FrequencyCap       *int
+	FrequencyCapExpire *int
+}
+
+func (b CampaignBudget) String() string {
+	return Stringify(b)
+}
+
+func (b CampaignBudget) validate() error {
+	var errs []error
+
+	if b.DailyBudget != nil && *b.DailyBudget < 0 {
+		errs = append(errs, fmt.Errorf("invalid daily budget: must not be negative, but got %v", *b.DailyBudget))
+	}
+
+	if b.TotalBudget != nil && *b.TotalBudget < 0 {
+		errs = append(errs, fmt.Errorf("invalid total budget: must not be negative, but got %v", *b.TotalBudget))
+	}
+
+	if b.DailyBudget != nil && b.TotalBudget != nil && *b.TotalBudget > 0 && *b.DailyBudget > *b.TotalBudget {
+		errs = append(errs, errors.New("invalid budget: daily budget cannot exceed total budget"))
+	}
+
+	if b.Delivery != nil && *b.Delivery != EvenDelivery && *b.Delivery != FastDelivery {
+		errs = append(errs, fmt.Errorf("unsupported delivery type \"%s\"", *b.Delivery))
+	}
+
+	if b.FrequencyCap != nil && *b.FrequencyCap < 0 {
+		errs = append(errs, fmt.Errorf("invalid frequency cap: must not be negative, but got %d", *b.FrequencyCap))
+	}
+
+	if b.FrequencyCap != nil && *b.FrequencyCap > 0 && (b.FrequencyCapExpire == nil || *b.FrequencyCapExpire <= 0) {
+		errs = append(errs, errors.New("invalid frequency cap: expire hours must be set when frequency cap is set"))
+	}
+
+	return errors.Join(errs...)
 }
 
 type CampaignType struct {
@@ -93,7 +149,9 @@ func (c *CampaignsService) List(ctx context.Context, opts *CampaignListOptions)
 
 func (c *CampaignsService) Get(ctx context.Context, id int, isDetailed bool) (*Campaign, *http.Response, error) {
 	u := fmt.Sprintf("campaigns/%d", id)
-	u, err := addOptions(u, struct{ detailed bool }{detailed: isDetailed})
+	u, err := addOptions(u, struct {
+		Detailed bool `url:"detailed,omitempty"`
+	}{Detailed: isDetailed})
 	if err != nil {
 		return nil, nil, err
 	}
@@ -160,6 +218,11 @@ func (c *CampaignsService) BlockCategories(ctx context.Context, campaignID int,
 	return c.ToggleCategories(ctx, campaignID, categories, TargetingOptions{Block})
 }
 
+const (
+	CampaignPaused = 0
+	CampaignActive = 1
+)
+
 type Operation string
 
 const (
@@ -177,3 +240,212 @@ func (c *CampaignsService) ChangeVariationStatus(ctx context.Context, campaignID
 
 	return c.client.Do(ctx, req, nil)
 }
+
+func (c *CampaignsService) ChangeCampaignStatus(ctx context.Context, opts Operation, campaignIDs ...int) (*http.Response, error) {
+	u := fmt.Sprintf("campaigns/%s", opts)
+
+	if len(campaignIDs) == 0 {
+		return nil, errors.New("campaign ids cannot be empty")
+	}
+
+	body := struct {
+		CampaignIDs []int `json:"campaign_ids"`
+	}{campaignIDs}
+
+	req, err := c.client.NewRequest(http.MethodPut, u, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.client.Do(ctx, req, nil)
+}
+
+func (c *CampaignsService) Create(ctx context.Context, campaign *CampaignData) (*CampaignData, *http.Response, error) {
+	u := "campaigns"
+
+	req, err := c.client.NewRequest(http.MethodPost, u, campaign)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	campaignResponse := struct {
+		Result CampaignData `json:"result,omitempty"`
+	}{}
+
+	resp, err := c.client.Do(ctx, req, &campaignResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &campaignResponse.Result, resp, nil
+}
+
+func (c *CampaignsService) Update(ctx context.Context, id int, campaign *CampaignData) (*http.Response, error) {
+	u := fmt.Sprintf("campaigns/%d", id)
+
+	req, err := c.client.NewRequest(http.MethodPut, u, campaign)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.client.Do(ctx, req, nil)
+}
+
+func (c *CampaignsService) GetBudget(ctx context.Context, id int) (*CampaignBudget, *http.Response, error) {
+	campaign, resp, err := c.Get(ctx, id, false)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if campaign.Campaign == nil {
+		return nil, resp, errors.New("campaign response is missing campaign data")
+	}
+
+	data := campaign.Campaign
+
+	return &CampaignBudget{
+		DailyBudget:        data.DailyBudget,
+		TotalBudget:        data.TotalBudget,
+		Delivery:           data.Delivery,
+		FrequencyCap:       data.FrequencyCap,
+		FrequencyCapExpire: data.FrequencyCapExpire,
+	}, resp, nil
+}
+
+func (c *CampaignsService) UpdateBudget(ctx context.Context, id int, budget CampaignBudget) (*http.Response, error) {
+	if err := budget.validate(); err != nil {
+		return nil, err
+	}
+
+	if (budget.DailyBudget == nil) != (budget.TotalBudget == nil) {
+		current, resp, err := c.GetBudget(ctx, id)
+		if err != nil {
+			return resp, fmt.Errorf("fetching current budget: %w", err)
+		}
+
+		limits := CampaignBudget{DailyBudget: budget.DailyBudget, TotalBudget: budget.TotalBudget}
+		if limits.DailyBudget == nil {
+			limits.DailyBudget = current.DailyBudget
+		}
+
+		if limits.TotalBudget == nil {
+			limits.TotalBudget = current.TotalBudget
+		}
+
+		if err := limits.validate(); err != nil {
+			return resp, err
+		}
+	}
+
+	return c.Update(ctx, id, &CampaignData{
+		DailyBudget:        budget.DailyBudget,
+		TotalBudget:        budget.TotalBudget,
+		Delivery:           budget.Delivery,
+		FrequencyCap:       budget.FrequencyCap,
+		FrequencyCapExpire: budget.FrequencyCapExpire,
+	})
+}
+
+func (c *CampaignsService) SetDailyBudget(ctx context.Context, id int, amount float64) (*http.Response, error) {
+	return c.UpdateBudget(ctx, id, CampaignBudget{DailyBudget: &amount})
+}
+
+func (c *CampaignsService) SetFrequencyCap(ctx context.Context, id int, impressions int, expireHours int) (*http.Response, error) {
+	return c.UpdateBudget(ctx, id, CampaignBudget{FrequencyCap: &impressions, FrequencyCapExpire: &expireHours})
+}
+
+func (c *CampaignsService) ToggleZones(ctx context.Context, campaignID int, zones []CampaignZones, opts TargetingOptions) (*http.Response, error) {
+	u := fmt.Sprintf("campaigns/%d/targeted/zones", campaignID)
+
+	if len(zones) == 0 {
+		return nil, errors.New("zones array cannot be empty")
+	}
+
+	var method string
+
+	switch opts.Type {
+	case Target:
+		method = http.MethodPost
+	case Block:
+		method = http.MethodDelete
+	default:
+		return nil, fmt.Errorf("unsupported targeting type %d", opts.Type)
+	}
+
+	req, err := c.client.NewRequest(method, u, zones)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.client.Do(ctx, req, nil)
+}
+
+func (c *CampaignsService) TargetZones(ctx context.Context, campaignID int, zones []CampaignZones) (*http.Response, error) {
+	return c.ToggleZones(ctx, campaignID, zones, TargetingOptions{Target})
+}
+
+func (c *CampaignsService) UntargetZones(ctx context.Context, campaignID int, zones []CampaignZones) (*http.Response, error) {
+	return c.ToggleZones(ctx, campaignID, zones, TargetingOptions{Block})
+}
+
+func (c *CampaignsService) UpdateZonePrices(ctx context.Context, campaignID int, zones []CampaignZones) (*http.Response, error) {
+	u := fmt.Sprintf("campaigns/%d/targeted/zones", campaignID)
+
+	if len(zones) == 0 {
+		return nil, errors.New("zones array cannot be empty")
+	}
+
+	for _, zone := range zones {
+		if zone.ZoneID == nil || zone.Price == nil {
+			return nil, errors.New("zone id and price must be set for every zone")
+		}
+	}
+
+	req, err := c.client.NewRequest(http.MethodPut, u, zones)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.client.Do(ctx, req, nil)
+}
+
+type BlockOperation uint8
+
+const (
+	AddToBlocklist BlockOperation = 1 + iota
+	RemoveFromBlocklist
+)
+
+func (c *CampaignsService) ToggleBlockedZones(ctx context.Context, campaignID int, zoneIDs []int, op BlockOperation) (*http.Response, error) {
+	u := fmt.Sprintf("campaigns/%d/blocked/zones", campaignID)
+
+	if len(zoneIDs) == 0 {
+		return nil, errors.New("zones array cannot be empty")
+	}
+
+	var method string
+
+	switch op {
+	case AddToBlocklist:
+		method = http.MethodPost
+	case RemoveFromBlocklist:
+		method = http.MethodDelete
+	default:
+		return nil, fmt.Errorf("unsupported block operation %d", op)
+	}
+
+	req, err := c.client.NewRequest(method, u, zoneIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.client.Do(ctx, req, nil)
+}
+
+func (c *CampaignsService) BlockZones(ctx context.Context, campaignID int, zoneIDs []int) (*http.Response, error) {
+	return c.ToggleBlockedZones(ctx, campaignID, zoneIDs, AddToBlocklist)
+}
+
+func (c *CampaignsService) UnblockZones(ctx context.Context, campaignID int, zoneIDs []int) (*http.Response, error) {
+	return c.ToggleBlockedZones(ctx, campaignID, zoneIDs, RemoveFromBlocklist)
+}
@@ -0,0 +1,47 @@
+package exoclick
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestSetDailyBudgetChecksCurrentTotal(t *testing.T) {
+	var updates int
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /login", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"token":"test","expires_in":3600}`))
+	})
+	mux.HandleFunc("GET /campaigns/7", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"result":{"campaign":{"id":7,"daily_budget":10,"total_budget":50}}}`))
+	})
+	mux.HandleFunc("PUT /campaigns/7", func(w http.ResponseWriter, r *http.Request) {
+		updates++
+		w.Write([]byte(`{"result":{}}`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := NewClient(nil, "token")
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+
+	if _, err := client.Campaigns.SetDailyBudget(context.Background(), 7, 80); err == nil {
+		t.Error("expected an error for a daily budget above the current total budget")
+	}
+
+	if updates != 0 {
+		t.Fatalf("expected no update request, but got %d", updates)
+	}
+
+	if _, err := client.Campaigns.SetDailyBudget(context.Background(), 7, 40); err != nil {
+		t.Fatal(err)
+	}
+
+	if updates != 1 {
+		t.Errorf("expected one update request, but got %d", updates)
+	}
+}
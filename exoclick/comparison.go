@@ -0,0 +1,181 @@
+package exoclick
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+type ComparisonPeriod uint8
+
+const (
+	PreviousPeriod ComparisonPeriod = 1 + iota
+	PreviousYear
+)
+
+type Presence uint8
+
+const (
+	InBoth Presence = iota
+	CurrentOnly
+	PreviousOnly
+)
+
+func (p Presence) String() string {
+	switch p {
+	case CurrentOnly:
+		return "current_only"
+	case PreviousOnly:
+		return "previous_only"
+	default:
+		return "both"
+	}
+}
+
+type MetricDelta struct {
+	Current       float64
+	Previous      float64
+	Delta         float64
+	PercentChange *float64
+}
+
+type ComparisonRow struct {
+	Current  *Statistic
+	Previous *Statistic
+	Presence Presence
+	Metrics  map[StatisticsField]MetricDelta
+}
+
+func (r ComparisonRow) String() string {
+	return Stringify(r)
+}
+
+type Comparison struct {
+	CurrentFrom  CustomDate
+	CurrentTo    CustomDate
+	PreviousFrom CustomDate
+	PreviousTo   CustomDate
+	Rows         []*ComparisonRow
+}
+
+func (s *StatisticsService) Compare(ctx context.Context, opts *StatisticsOptions, period ComparisonPeriod) (*Comparison, error) {
+	if opts.Filter.DateFrom.IsZero() || opts.Filter.DateTo.IsZero() {
+		return nil, errors.New("date from and date to must be set")
+	}
+
+	from, to := opts.Filter.DateFrom.Time, opts.Filter.DateTo.Time
+
+	var shift, unshift func(time.Time) (time.Time, bool)
+
+	switch period {
+	case PreviousPeriod:
+		days := calendarDays(from, to) + 1
+		shift = func(t time.Time) (time.Time, bool) { return t.AddDate(0, 0, -days), true }
+		unshift = func(t time.Time) (time.Time, bool) { return t.AddDate(0, 0, days), true }
+	case PreviousYear:
+		shift = func(t time.Time) (time.Time, bool) { return shiftYears(t, -1) }
+		unshift = func(t time.Time) (time.Time, bool) { return shiftYears(t, 1) }
+	default:
+		return nil, fmt.Errorf("unsupported comparison period %d", period)
+	}
+
+	previousFrom, _ := shift(from)
+	previousTo, _ := shift(to)
+
+	previousOpts := *opts
+	previousOpts.Filter.DateFrom = CustomDate{previousFrom}
+	previousOpts.Filter.DateTo = CustomDate{previousTo}
+
+	current, _, err := s.GetStatisticsCSV(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	previous, _, err := s.GetStatisticsCSV(ctx, &previousOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	comparison := &Comparison{
+		CurrentFrom:  opts.Filter.DateFrom,
+		CurrentTo:    opts.Filter.DateTo,
+		PreviousFrom: previousOpts.Filter.DateFrom,
+		PreviousTo:   previousOpts.Filter.DateTo,
+	}
+
+	var metrics []StatisticsField
+	for _, field := range opts.OutputCsvFields {
+		if slices.Contains(MetricFields, field) {
+			metrics = append(metrics, field)
+		}
+	}
+
+	index := make(map[string]*ComparisonRow)
+
+	for _, row := range current {
+		r := &ComparisonRow{Current: row, Presence: CurrentOnly}
+		index[row.Key(opts.GroupBy...)] = r
+		comparison.Rows = append(comparison.Rows, r)
+	}
+
+	for _, row := range previous {
+		key := *row
+		mapped := true
+
+		if row.Date != nil {
+			var date time.Time
+			date, mapped = unshift(*row.Date)
+			key.Date = &date
+		}
+
+		if r, ok := index[key.Key(opts.GroupBy...)]; ok && mapped && r.Previous == nil {
+			r.Previous = row
+			r.Presence = InBoth
+			continue
+		}
+
+		comparison.Rows = append(comparison.Rows, &ComparisonRow{Previous: row, Presence: PreviousOnly})
+	}
+
+	for _, r := range comparison.Rows {
+		r.Metrics = make(map[StatisticsField]MetricDelta, len(metrics))
+
+		for _, field := range metrics {
+			var d MetricDelta
+			if r.Current != nil {
+				d.Current, _ = r.Current.Metric(field)
+			}
+			if r.Previous != nil {
+				d.Previous, _ = r.Previous.Metric(field)
+			}
+
+			d.Delta = d.Current - d.Previous
+			if d.Previous != 0 {
+				change := d.Delta / d.Previous * 100
+				d.PercentChange = &change
+			}
+
+			r.Metrics[field] = d
+		}
+	}
+
+	return comparison, nil
+}
+
+func calendarDays(from, to time.Time) int {
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	end := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+
+	return int(end.Sub(start) / Day)
+}
+
+func shiftYears(t time.Time, years int) (time.Time, bool) {
+	shifted := time.Date(t.Year()+years, t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	if shifted.Month() != t.Month() {
+		return shifted.AddDate(0, 0, -shifted.Day()), false
+	}
+
+	return shifted, true
+}
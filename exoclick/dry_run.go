@@ -0,0 +1,124 @@
+package exoclick
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	headerDryRun = "X-Dry-Run"
+
+	MaxPlannedRequests = 10000
+)
+
+var ErrDryRunPlanFull = fmt.Errorf("dry-run plan is full: more than %d planned requests", MaxPlannedRequests)
+
+type PlannedRequest struct {
+	Method string          `json:"method"`
+	URL    string          `json:"url"`
+	Body   json.RawMessage `json:"body,omitempty"`
+	Time   time.Time       `json:"time"`
+}
+
+func (p PlannedRequest) String() string {
+	if len(p.Body) == 0 {
+		return p.Method + " " + p.URL
+	}
+
+	return p.Method + " " + p.URL + " " + string(p.Body)
+}
+
+type dryRunContextKey struct{}
+
+func WithDryRun(ctx context.Context) context.Context {
+	return context.WithValue(ctx, dryRunContextKey{}, true)
+}
+
+func IsDryRun(ctx context.Context) bool {
+	dryRun, _ := ctx.Value(dryRunContextKey{}).(bool)
+	return dryRun
+}
+
+func (c *Client) SetDryRun(dryRun bool) {
+	c.dryRun.Store(dryRun)
+}
+
+func (c *Client) DryRun() bool {
+	return c.dryRun.Load()
+}
+
+func (c *Client) DryRunPlan() []PlannedRequest {
+	c.planMu.Lock()
+	defer c.planMu.Unlock()
+
+	plan := make([]PlannedRequest, len(c.plan))
+	copy(plan, c.plan)
+
+	return plan
+}
+
+func (c *Client) ResetDryRunPlan() {
+	c.planMu.Lock()
+	c.plan = nil
+	c.planMu.Unlock()
+}
+
+func isMutating(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	}
+
+	return !strings.Contains(req.URL.Path, "/statistics/") && !strings.HasSuffix(req.URL.Path, "/login")
+}
+
+func (c *Client) planRequest(ctx context.Context, req *http.Request) (*http.Response, error) {
+	planned := PlannedRequest{
+		Method: req.Method,
+		URL:    req.URL.String(),
+		Time:   time.Now(),
+	}
+
+	if req.Body != nil {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		if json.Valid(body) {
+			planned.Body = bytes.TrimSpace(body)
+		}
+	}
+
+	c.planMu.Lock()
+	if len(c.plan) >= MaxPlannedRequests {
+		c.planMu.Unlock()
+		return nil, ErrDryRunPlanFull
+	}
+	c.plan = append(c.plan, planned)
+
+	body := "{}"
+	if req.Method == http.MethodPost {
+		c.placeholderID--
+		body = fmt.Sprintf(`{"result":{"id":%[1]d,"idvariation":%[1]d}}`, c.placeholderID)
+	}
+	c.planMu.Unlock()
+
+	resp := &http.Response{
+		Status:     http.StatusText(http.StatusOK),
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req.WithContext(ctx),
+	}
+	resp.Header.Set(headerDryRun, "1")
+
+	return resp, nil
+}
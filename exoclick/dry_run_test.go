@@ -0,0 +1,33 @@
+package exoclick
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestDryRunPlanReportsWhenFull(t *testing.T) {
+	client := NewClient(nil, "token")
+	client.SetDryRun(true)
+
+	for i := 0; i < MaxPlannedRequests; i++ {
+		if _, err := client.Campaigns.ChangeCampaignStatus(context.Background(), Pause, i+1); err != nil {
+			t.Fatalf("request %d: %v", i, err)
+		}
+	}
+
+	_, err := client.Campaigns.ChangeCampaignStatus(context.Background(), Pause, 1)
+	if !errors.Is(err, ErrDryRunPlanFull) {
+		t.Fatalf("expected ErrDryRunPlanFull, but got %v", err)
+	}
+
+	if got := len(client.DryRunPlan()); got != MaxPlannedRequests {
+		t.Errorf("expected %d planned requests, but got %d", MaxPlannedRequests, got)
+	}
+
+	client.ResetDryRunPlan()
+
+	if _, err := client.Campaigns.ChangeCampaignStatus(context.Background(), Pause, 1); err != nil {
+		t.Errorf("expected the reset plan to accept requests, but got %v", err)
+	}
+}
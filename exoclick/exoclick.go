@@ -14,6 +14,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/go-querystring/query"
@@ -42,13 +43,24 @@ type Client struct {
 	rateMu     sync.Mutex
 	rateLimits [Categories]Rate
 
+	StatisticsCache *StatisticsCache
+
+	dryRun        atomic.Bool
+	planMu        sync.Mutex
+	plan          []PlannedRequest
+	placeholderID int
+
 	common service
 
 	Campaigns   *CampaignsService
 	Category    *CategoryService
 	File        *FileService
+	Goals       *GoalsService
 	Marketplace *MarketplaceService
 
+	Sites *SitesService
+	Zones *ZonesService
+
 	Statistics *StatisticsService
 }
 
@@ -115,8 +127,12 @@ func (c *Client) initialize() {
 	c.Campaigns = (*CampaignsService)(&c.common)
 	c.Category = (*CategoryService)(&c.common)
 	c.File = (*FileService)(&c.common)
+	c.Goals = (*GoalsService)(&c.common)
 	c.Marketplace = (*MarketplaceService)(&c.common)
 
+	c.Sites = (*SitesService)(&c.common)
+	c.Zones = (*ZonesService)(&c.common)
+
 	c.Statistics = (*StatisticsService)(&c.common)
 }
 
@@ -127,6 +143,10 @@ func (c *Client) bareDo(ctx context.Context, caller *http.Client, req *http.Requ
 
 	req = req.WithContext(ctx)
 
+	if (c.dryRun.Load() || IsDryRun(ctx)) && isMutating(req) {
+		return c.planRequest(ctx, req)
+	}
+
 	rateLimitCategory := GetRateLimitCategory(req.URL.Path)
 
 	err := c.checkRateLimitBeforeDo(req, rateLimitCategory)
@@ -220,6 +240,30 @@ func (c *Client) NewRequest(method, urlStr string, body interface{}, opts ...str
 	return req, nil
 }
 
+func (c *Client) NewUploadRequest(urlStr string, reader io.Reader, mediaType string) (*http.Request, error) {
+	if !strings.HasSuffix(c.BaseURL.Path, "/") {
+		return nil, fmt.Errorf("BaseURL must have a trailing slash, but %q does not", c.BaseURL)
+	}
+
+	u, err := c.BaseURL.Parse(urlStr)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, u.String(), reader)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", mediaType)
+
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+
+	return req, nil
+}
+
 func (c *Client) Login() (*http.Response,error) {
 	url, err := c.BaseURL.Parse("login")
 	if err != nil {
@@ -398,6 +442,22 @@ func (tz *TimeZone) MarshalJSON() ([]byte, error) {
 	return json.Marshal(tz.String())
 }
 
+func (tz *TimeZone) UnmarshalJSON(b []byte) error {
+	var name string
+	if err := json.Unmarshal(b, &name); err != nil {
+		return err
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return err
+	}
+
+	tz.Location = loc
+
+	return nil
+}
+
 type RateLimitCategory uint8
 
 const (
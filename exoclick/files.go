@@ -1,8 +1,12 @@
 package exoclick
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
 	"net/http"
 )
 
@@ -86,3 +90,76 @@ func (f *FileService) List(ctx context.Context, opts *FileListOptions) ([]*File,
 
 	return filesResponse.Result, resp, nil
 }
+
+func (f *FileService) Get(ctx context.Context, id int) (*File, *http.Response, error) {
+	u := fmt.Sprintf("library/file/%d", id)
+
+	req, err := f.client.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fileResponse := struct {
+		Result File `json:"result,omitempty"`
+	}{}
+
+	resp, err := f.client.Do(ctx, req, &fileResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &fileResponse.Result, resp, nil
+}
+
+type FileUploadOptions struct {
+	Type     FileType
+	FileName string
+}
+
+func (f *FileService) Upload(ctx context.Context, content io.Reader, opts *FileUploadOptions) (*File, *http.Response, error) {
+	if opts.Type == "" {
+		return nil, nil, errors.New("type must be set")
+	}
+
+	if opts.FileName == "" {
+		return nil, nil, errors.New("file name must be set")
+	}
+
+	u := "library/file"
+
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+
+	if err := w.WriteField("type", string(opts.Type)); err != nil {
+		return nil, nil, err
+	}
+
+	part, err := w.CreateFormFile("file", opts.FileName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if _, err := io.Copy(part, content); err != nil {
+		return nil, nil, err
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, nil, err
+	}
+
+	req, err := f.client.NewUploadRequest(u, body, w.FormDataContentType())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fileResponse := struct {
+		Result File `json:"result,omitempty"`
+	}{}
+
+	resp, err := f.client.Do(ctx, req, &fileResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &fileResponse.Result, resp, nil
+}
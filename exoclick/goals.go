@@ -0,0 +1,89 @@
+package exoclick
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+type GoalsService service
+
+type Goal struct {
+	ID          *int        `json:"id,omitempty"`
+	Name        *string     `json:"name,omitempty"`
+	Hash        *string     `json:"hash,omitempty"`
+	Type        *int        `json:"type,omitempty"`
+	Value       *float64    `json:"value,omitempty"`
+	Status      *int        `json:"status,omitempty"`
+	DateCreated *CustomDate `json:"date_created,omitempty"`
+}
+
+func (g Goal) String() string {
+	return Stringify(g)
+}
+
+type GoalListOptions struct {
+	OrderBy string `url:"orderBy,omitempty"`
+
+	ListOptions
+}
+
+func (g *GoalsService) List(ctx context.Context, opts *GoalListOptions) ([]*Goal, *http.Response, error) {
+	u := "goals"
+
+	if opts.OrderBy == "" {
+		opts.OrderBy = "a:id"
+	}
+
+	u, err := addOptions(u, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := g.client.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	goalsResponse := struct {
+		Result []*Goal `json:"result,omitempty"`
+	}{}
+
+	resp, err := g.client.Do(ctx, req, &goalsResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return goalsResponse.Result, resp, nil
+}
+
+func (g *GoalsService) Create(ctx context.Context, goal *Goal) (*Goal, *http.Response, error) {
+	u := "goals"
+
+	req, err := g.client.NewRequest(http.MethodPost, u, goal)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	goalResponse := struct {
+		Result Goal `json:"result,omitempty"`
+	}{}
+
+	resp, err := g.client.Do(ctx, req, &goalResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &goalResponse.Result, resp, nil
+}
+
+func (g *GoalsService) Update(ctx context.Context, id int, goal *Goal) (*http.Response, error) {
+	u := fmt.Sprintf("goals/%d", id)
+
+	req, err := g.client.NewRequest(http.MethodPut, u, goal)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.client.Do(ctx, req, nil)
+}
@@ -0,0 +1,66 @@
+package exoclick
+
+import (
+	"time"
+)
+
+func (s *Statistic) Timestamp() (time.Time, bool) {
+	if s.Date == nil {
+		return time.Time{}, false
+	}
+
+	hour := 0
+	if s.Hour != nil {
+		hour = *s.Hour
+	}
+
+	return HourStart(*s.Date, hour), true
+}
+
+func HourStart(date time.Time, hour int) time.Time {
+	t := time.Date(date.Year(), date.Month(), date.Day(), hour, 0, 0, 0, date.Location())
+
+	if t.Hour() != hour {
+		return time.Date(date.Year(), date.Month(), date.Day(), hour+1, 0, 0, 0, date.Location())
+	}
+
+	if earlier := t.Add(-time.Hour); earlier.Hour() == hour && earlier.Day() == t.Day() {
+		return earlier
+	}
+
+	return t
+}
+
+func HoursInDay(date time.Time, loc *time.Location) int {
+	start := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc)
+	end := time.Date(date.Year(), date.Month(), date.Day()+1, 0, 0, 0, 0, loc)
+
+	return int(end.Sub(start) / time.Hour)
+}
+
+func DayHours(date time.Time, loc *time.Location) []time.Time {
+	start := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc)
+
+	hours := make([]time.Time, HoursInDay(date, loc))
+	for i := range hours {
+		hours[i] = start.Add(time.Duration(i) * time.Hour)
+	}
+
+	return hours
+}
+
+func localizeStatistics(statistics []*Statistic, tz *TimeZone) {
+	for _, s := range statistics {
+		s.Date = localizeDate(s.Date, tz)
+	}
+}
+
+func localizeDate(date *time.Time, tz *TimeZone) *time.Time {
+	if date == nil || tz == nil || tz.Location == nil {
+		return date
+	}
+
+	localized := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, tz.Location)
+
+	return &localized
+}
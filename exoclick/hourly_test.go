@@ -0,0 +1,73 @@
+package exoclick
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func TestTimestampUsesWallClockHour(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		date time.Time
+		hour int
+		want time.Time
+	}{
+		{"regular day", time.Date(2024, time.June, 1, 0, 0, 0, 0, loc), 5, time.Date(2024, time.June, 1, 3, 0, 0, 0, time.UTC)},
+		{"before spring forward", time.Date(2024, time.March, 31, 0, 0, 0, 0, loc), 1, time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC)},
+		{"skipped hour", time.Date(2024, time.March, 31, 0, 0, 0, 0, loc), 2, time.Date(2024, time.March, 31, 1, 0, 0, 0, time.UTC)},
+		{"after spring forward", time.Date(2024, time.March, 31, 0, 0, 0, 0, loc), 3, time.Date(2024, time.March, 31, 1, 0, 0, 0, time.UTC)},
+		{"last hour of spring forward", time.Date(2024, time.March, 31, 0, 0, 0, 0, loc), 23, time.Date(2024, time.March, 31, 21, 0, 0, 0, time.UTC)},
+		{"repeated hour", time.Date(2024, time.October, 27, 0, 0, 0, 0, loc), 2, time.Date(2024, time.October, 27, 0, 0, 0, 0, time.UTC)},
+		{"after fall back", time.Date(2024, time.October, 27, 0, 0, 0, 0, loc), 3, time.Date(2024, time.October, 27, 2, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Statistic{Date: &tt.date, Hour: &tt.hour}
+
+			got, ok := s.Timestamp()
+			if !ok {
+				t.Fatal("expected a timestamp")
+			}
+
+			if !got.Equal(tt.want) {
+				t.Errorf("expected %s, but got %s", tt.want.In(loc), got)
+			}
+		})
+	}
+}
+
+func TestHoursInDay(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		date  time.Time
+		hours int
+	}{
+		{"spring forward", time.Date(2024, time.March, 31, 0, 0, 0, 0, loc), 23},
+		{"fall back", time.Date(2024, time.October, 27, 0, 0, 0, 0, loc), 25},
+		{"regular day", time.Date(2024, time.June, 1, 0, 0, 0, 0, loc), 24},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HoursInDay(tt.date, loc); got != tt.hours {
+				t.Errorf("expected %d hours, but got %d", tt.hours, got)
+			}
+
+			if got := len(DayHours(tt.date, loc)); got != tt.hours {
+				t.Errorf("expected %d day hours, but got %d", tt.hours, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,238 @@
+package exoclick
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+type Macro string
+
+const (
+	MacroConversionsTracking Macro = "conversions_tracking"
+	MacroCampaignID          Macro = "campaign_id"
+	MacroCampaignName        Macro = "campaign_name"
+	MacroVariationID         Macro = "variation_id"
+	MacroZoneID              Macro = "zone_id"
+	MacroSiteID              Macro = "site_id"
+	MacroSrcHostname         Macro = "src_hostname"
+	MacroCategoryID          Macro = "category_id"
+	MacroCategoryName        Macro = "category_name"
+	MacroCountry             Macro = "country"
+	MacroLanguage            Macro = "lang"
+	MacroOS                  Macro = "os"
+	MacroBrowser             Macro = "browser"
+	MacroDevice              Macro = "device"
+	MacroCarrier             Macro = "carrier"
+	MacroFormat              Macro = "format"
+	MacroActualBid           Macro = "actual_bid"
+	MacroTime                Macro = "time"
+)
+
+var SupportedMacros = []Macro{
+	MacroConversionsTracking,
+	MacroCampaignID,
+	MacroCampaignName,
+	MacroVariationID,
+	MacroZoneID,
+	MacroSiteID,
+	MacroSrcHostname,
+	MacroCategoryID,
+	MacroCategoryName,
+	MacroCountry,
+	MacroLanguage,
+	MacroOS,
+	MacroBrowser,
+	MacroDevice,
+	MacroCarrier,
+	MacroFormat,
+	MacroActualBid,
+	MacroTime,
+}
+
+var SampleMacroValues = map[Macro]string{
+	MacroConversionsTracking: "ADQ1M2Y0NjdkZTk0ZTY1YzNkMDAw",
+	MacroCampaignID:          "1234567",
+	MacroCampaignName:        "Sample Campaign",
+	MacroVariationID:         "7654321",
+	MacroZoneID:              "3456789",
+	MacroSiteID:              "987654",
+	MacroSrcHostname:         "example.com",
+	MacroCategoryID:          "2",
+	MacroCategoryName:        "Sample Category",
+	MacroCountry:             "USA",
+	MacroLanguage:            "en",
+	MacroOS:                  "Windows",
+	MacroBrowser:             "Chrome",
+	MacroDevice:              "Desktop",
+	MacroCarrier:             "Verizon",
+	MacroFormat:              "banner",
+	MacroActualBid:           "0.05",
+	MacroTime:                "1700000000",
+}
+
+func (m Macro) Placeholder() string {
+	return "{" + string(m) + "}"
+}
+
+type TrackingURLBuilder struct {
+	base   *url.URL
+	params []string
+	errs   []error
+}
+
+func NewTrackingURL(base string) *TrackingURLBuilder {
+	b := &TrackingURLBuilder{}
+
+	u, err := url.Parse(base)
+	if err != nil {
+		b.errs = append(b.errs, err)
+		return b
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		b.errs = append(b.errs, fmt.Errorf("invalid tracking url scheme: \"%s\"", u.Scheme))
+	}
+
+	b.base = u
+
+	return b
+}
+
+func (b *TrackingURLBuilder) Macro(name string, macro Macro) *TrackingURLBuilder {
+	if !slices.Contains(SupportedMacros, macro) {
+		b.errs = append(b.errs, fmt.Errorf("unsupported macro: \"%s\"", macro))
+		return b
+	}
+
+	b.params = append(b.params, url.QueryEscape(name)+"="+macro.Placeholder())
+
+	return b
+}
+
+func (b *TrackingURLBuilder) Param(name, value string) *TrackingURLBuilder {
+	b.params = append(b.params, url.QueryEscape(name)+"="+url.QueryEscape(value))
+	return b
+}
+
+func (b *TrackingURLBuilder) Build() (string, error) {
+	if err := errors.Join(b.errs...); err != nil {
+		return "", err
+	}
+
+	u := *b.base
+	query := u.RawQuery
+	u.RawQuery = ""
+
+	params := b.params
+	if query != "" {
+		params = append([]string{query}, params...)
+	}
+
+	s := u.String()
+	if len(params) > 0 {
+		s += "?" + strings.Join(params, "&")
+	}
+
+	return s, ValidateTrackingURL(s)
+}
+
+func ParseMacros(raw string) ([]Macro, error) {
+	var macros []Macro
+
+	for rest := raw; ; {
+		open := strings.IndexAny(rest, "{}")
+		if open == -1 {
+			break
+		}
+
+		if rest[open] == '}' {
+			return nil, fmt.Errorf("unbalanced \"}\" in tracking url at \"%s\"", rest[open:])
+		}
+
+		end := strings.IndexAny(rest[open+1:], "{}")
+		if end == -1 || rest[open+1+end] == '{' {
+			return nil, fmt.Errorf("unterminated macro in tracking url at \"%s\"", rest[open:])
+		}
+
+		macros = append(macros, Macro(rest[open+1:open+1+end]))
+		rest = rest[open+1+end+1:]
+	}
+
+	return macros, nil
+}
+
+func ValidateTrackingURL(raw string) error {
+	var errs []error
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return err
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		errs = append(errs, fmt.Errorf("invalid tracking url scheme: \"%s\"", u.Scheme))
+	}
+
+	if u.Host == "" {
+		errs = append(errs, errors.New("tracking url must have a host"))
+	}
+
+	macros, err := ParseMacros(raw)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	for _, macro := range macros {
+		if !slices.Contains(SupportedMacros, macro) {
+			errs = append(errs, fmt.Errorf("unsupported macro: \"%s\"", macro.Placeholder()))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func ExpandMacros(raw string, values map[Macro]string) (string, error) {
+	if err := ValidateTrackingURL(raw); err != nil {
+		return "", err
+	}
+
+	macros, _ := ParseMacros(raw)
+
+	var errs []error
+
+	for _, macro := range macros {
+		if _, ok := values[macro]; !ok {
+			errs = append(errs, fmt.Errorf("missing value for macro: \"%s\"", macro.Placeholder()))
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return "", err
+	}
+
+	expand := func(s string, escape func(string) string) string {
+		for _, macro := range macros {
+			s = strings.ReplaceAll(s, macro.Placeholder(), escape(values[macro]))
+		}
+
+		return s
+	}
+
+	rest, fragment, hasFragment := strings.Cut(raw, "#")
+	path, query, hasQuery := strings.Cut(rest, "?")
+
+	expanded := expand(path, url.PathEscape)
+
+	if hasQuery {
+		expanded += "?" + expand(query, url.QueryEscape)
+	}
+
+	if hasFragment {
+		expanded += "#" + expand(fragment, url.PathEscape)
+	}
+
+	return expanded, nil
+}
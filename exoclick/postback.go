@@ -0,0 +1,169 @@
+package exoclick
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const defaultPostbackURL = "https://s.magsrv.com/tag.php"
+
+type Conversion struct {
+	GoalHash string
+	ClickID  string
+	Value    *float64
+}
+
+type PostbackSender struct {
+	client *http.Client
+
+	BaseURL *url.URL
+}
+
+func NewPostbackSender(httpClient *http.Client) *PostbackSender {
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+
+	baseURL, _ := url.Parse(defaultPostbackURL)
+
+	return &PostbackSender{client: httpClient, BaseURL: baseURL}
+}
+
+func (p *PostbackSender) URL(c Conversion) (string, error) {
+	if c.GoalHash == "" {
+		return "", errors.New("goal hash must be set")
+	}
+
+	if c.ClickID == "" || isUnresolvedMacro(c.ClickID) {
+		return "", errors.New("click id must be set")
+	}
+
+	q := url.Values{}
+	q.Set("goal", c.GoalHash)
+	q.Set("tag", c.ClickID)
+
+	if c.Value != nil {
+		q.Set("value", strconv.FormatFloat(*c.Value, 'f', -1, 64))
+	}
+
+	u := *p.BaseURL
+	u.RawQuery = q.Encode()
+
+	return u.String(), nil
+}
+
+func (p *PostbackSender) Send(ctx context.Context, c Conversion) (*http.Response, error) {
+	u, err := p.URL(c)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("User-Agent", defaultUserAgent)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return resp, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp, fmt.Errorf("postback failed with status %d", resp.StatusCode)
+	}
+
+	return resp, nil
+}
+
+type ClickParams struct {
+	ClickID      string
+	CampaignID   string
+	VariationID  string
+	ZoneID       string
+	SiteHostname string
+}
+
+var DefaultClickParams = ClickParams{
+	ClickID:      "clickid",
+	CampaignID:   "campaign_id",
+	VariationID:  "variation_id",
+	ZoneID:       "zone_id",
+	SiteHostname: "src_hostname",
+}
+
+type Click struct {
+	ClickID      string
+	CampaignID   *int
+	VariationID  *int
+	ZoneID       *int
+	SiteHostname string
+}
+
+func (c Click) String() string {
+	return Stringify(c)
+}
+
+type clickContextKey struct{}
+
+func ClickFromContext(ctx context.Context) (*Click, bool) {
+	click, ok := ctx.Value(clickContextKey{}).(*Click)
+	return click, ok
+}
+
+func ParseClick(r *http.Request, params ClickParams) (*Click, bool) {
+	q := r.URL.Query()
+
+	value := func(name string) string {
+		if name == "" {
+			return ""
+		}
+
+		v := q.Get(name)
+		if isUnresolvedMacro(v) {
+			return ""
+		}
+
+		return v
+	}
+
+	intValue := func(name string) *int {
+		v, err := strconv.Atoi(value(name))
+		if err != nil {
+			return nil
+		}
+
+		return &v
+	}
+
+	click := &Click{
+		ClickID:      value(params.ClickID),
+		CampaignID:   intValue(params.CampaignID),
+		VariationID:  intValue(params.VariationID),
+		ZoneID:       intValue(params.ZoneID),
+		SiteHostname: value(params.SiteHostname),
+	}
+
+	return click, click.ClickID != ""
+}
+
+func ClickHandler(params ClickParams, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if click, ok := ParseClick(r, params); ok {
+			r = r.WithContext(context.WithValue(r.Context(), clickContextKey{}, click))
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func isUnresolvedMacro(v string) bool {
+	return strings.HasPrefix(v, "{") && strings.HasSuffix(v, "}")
+}
@@ -0,0 +1,180 @@
+package exoclick
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	Country  StatisticsField = "country"
+	AdFormat StatisticsField = "ad_format"
+	Revenue  StatisticsField = "revenue"
+	ECPM     StatisticsField = "ecpm"
+)
+
+var ValidPublisherGroupByFields = []StatisticsField{
+	AdFormat,
+	Country,
+	Date,
+	Hour,
+	SiteID,
+	ZoneID,
+}
+
+var PublisherMetricFields = []StatisticsField{
+	Impressions,
+	Clicks,
+	Revenue,
+	ECPM,
+}
+
+type PublisherStatistic struct {
+	Date        *time.Time `csv:"date,omitempty" json:"date,omitempty"`
+	Hour        *int       `csv:"hour,omitempty" json:"hour,omitempty"`
+	SiteID      *int       `csv:"site_id,omitempty" json:"site_id,omitempty"`
+	SiteName    *string    `csv:"site_name,omitempty" json:"site_name,omitempty"`
+	ZoneID      *int       `csv:"zone_id,omitempty" json:"zone_id,omitempty"`
+	ZoneName    *string    `csv:"zone_name,omitempty" json:"zone_name,omitempty"`
+	Country     *string    `csv:"country,omitempty" json:"country,omitempty"`
+	AdFormat    *string    `csv:"ad_format,omitempty" json:"ad_format,omitempty"`
+	Impressions int        `csv:"impressions" json:"impressions"`
+	Clicks      int        `csv:"clicks" json:"clicks"`
+	Revenue     float64    `csv:"revenue" json:"revenue"`
+	ECPM        float64    `csv:"ecpm" json:"ecpm"`
+}
+
+func (s PublisherStatistic) String() string {
+	return Stringify(s)
+}
+
+func (s *PublisherStatistic) UnmarshalJSON(b []byte) error {
+	type publisherStatistic PublisherStatistic
+
+	aux := struct {
+		Date *CustomDate `json:"date,omitempty"`
+		*publisherStatistic
+	}{publisherStatistic: (*publisherStatistic)(s)}
+
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+
+	if aux.Date != nil {
+		s.Date = &aux.Date.Time
+	}
+
+	return nil
+}
+
+type PublisherStatisticsReport struct {
+	Rows  []*PublisherStatistic `json:"result"`
+	Total *PublisherStatistic   `json:"resultTotal,omitempty"`
+	Size  int                   `json:"resultSize"`
+}
+
+func (r PublisherStatisticsReport) String() string {
+	return Stringify(r)
+}
+
+type PublisherStatisticsOptions struct {
+	Timezone        *TimeZone                  `json:"timezone,omitempty"`
+	Filter          PublisherStatisticsFilters `json:"filter,omitempty"`
+	GroupBy         []StatisticsField          `json:"group_by,omitempty"`
+	OrderBy         []StatisticsOrderBy        `json:"order_by,omitempty"`
+	OutputCsvFields []StatisticsField          `json:"output_csv_fields,omitempty"`
+	Detailed        bool                       `json:"detailed,omitempty"`
+	ListOptions
+}
+
+type PublisherStatisticsFilters struct {
+	DateFrom CustomDate `json:"date_from,omitempty"`
+	DateTo   CustomDate `json:"date_to,omitempty"`
+	Hour     []int      `json:"hour,omitempty"`
+	SiteID   int        `json:"site_id,omitempty"`
+	ZoneID   int        `json:"zone_id,omitempty"`
+	Country  string     `json:"country,omitempty"`
+	AdFormat string     `json:"ad_format,omitempty"`
+}
+
+func (o *PublisherStatisticsOptions) query() reportQuery {
+	return reportQuery{
+		Timezone:     o.Timezone,
+		DateFrom:     o.Filter.DateFrom,
+		DateTo:       o.Filter.DateTo,
+		Hour:         o.Filter.Hour,
+		GroupBy:      o.GroupBy,
+		OrderBy:      o.OrderBy,
+		OutputFields: o.OutputCsvFields,
+		Detailed:     o.Detailed,
+		ValidGroupBy: ValidPublisherGroupByFields,
+	}
+}
+
+func (s *StatisticsService) GetPublisherStatistics(ctx context.Context, opts *PublisherStatisticsOptions) (*PublisherStatisticsReport, *http.Response, error) {
+	u := "statistics/p/global"
+
+	q := opts.query()
+	if err := q.validate(); err != nil {
+		return nil, nil, err
+	}
+
+	report := new(PublisherStatisticsReport)
+
+	resp, err := s.getJSON(ctx, u, opts, q, report)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	for _, row := range report.Rows {
+		row.Date = localizeDate(row.Date, opts.Timezone)
+	}
+
+	return report, resp, nil
+}
+
+func (s *StatisticsService) GetPublisherStatisticsCSV(ctx context.Context, opts *PublisherStatisticsOptions) ([]*PublisherStatistic, *http.Response, error) {
+	var statistics []*PublisherStatistic
+
+	resp, err := s.StreamPublisherStatisticsCSV(ctx, opts, func(statistic *PublisherStatistic) error {
+		statistics = append(statistics, statistic)
+		return nil
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return statistics, resp, nil
+}
+
+func (s *StatisticsService) StreamPublisherStatisticsCSV(ctx context.Context, opts *PublisherStatisticsOptions, fn func(*PublisherStatistic) error) (*http.Response, error) {
+	u := "statistics/p/global"
+
+	q := opts.query()
+	if err := q.validateCSV(); err != nil {
+		return nil, err
+	}
+
+	return s.streamCSV(ctx, u, opts, q, func(r io.Reader) error {
+		return decodeCSV(r, opts.OutputCsvFields, func(statistic *PublisherStatistic) error {
+			statistic.Date = localizeDate(statistic.Date, opts.Timezone)
+			return fn(statistic)
+		})
+	})
+}
+
+func UnmarshalPublisherStatisticsCSV(data io.Reader, outputCSVFields []StatisticsField) ([]*PublisherStatistic, error) {
+	var statistics []*PublisherStatistic
+
+	err := decodeCSV(data, outputCSVFields, func(statistic *PublisherStatistic) error {
+		statistics = append(statistics, statistic)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return statistics, nil
+}
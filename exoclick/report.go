@@ -0,0 +1,239 @@
+package exoclick
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+const (
+	Day  = 24 * time.Hour
+	Week = 7 * Day
+)
+
+type dateRangeFunc func(today time.Time) (from, to time.Time)
+
+type ReportBuilder struct {
+	opts      StatisticsOptions
+	metrics   []StatisticsField
+	dateRange dateRangeFunc
+	errs      []error
+}
+
+func NewReport() *ReportBuilder {
+	return &ReportBuilder{}
+}
+
+func (b *ReportBuilder) GroupBy(fields ...StatisticsField) *ReportBuilder {
+	for _, field := range fields {
+		if !slices.Contains(ValidGroupByFields, field) {
+			b.errs = append(b.errs, fmt.Errorf("invalid group by field: \"%s\"", field))
+			continue
+		}
+
+		if !slices.Contains(b.opts.GroupBy, field) {
+			b.opts.GroupBy = append(b.opts.GroupBy, field)
+		}
+	}
+
+	return b
+}
+
+func (b *ReportBuilder) Metrics(fields ...StatisticsField) *ReportBuilder {
+	for _, field := range fields {
+		if !slices.Contains(b.metrics, field) {
+			b.metrics = append(b.metrics, field)
+		}
+	}
+
+	return b
+}
+
+func (b *ReportBuilder) OrderBy(field StatisticsField, order OrderType) *ReportBuilder {
+	if order != Asc && order != Desc {
+		b.errs = append(b.errs, fmt.Errorf("invalid order type: \"%s\"", order))
+		return b
+	}
+
+	b.opts.OrderBy = append(b.opts.OrderBy, StatisticsOrderBy{Field: field, Order: order})
+
+	return b
+}
+
+func (b *ReportBuilder) Detailed() *ReportBuilder {
+	b.opts.Detailed = true
+	return b
+}
+
+func (b *ReportBuilder) Timezone(loc *time.Location) *ReportBuilder {
+	if loc == nil {
+		b.errs = append(b.errs, errors.New("timezone cannot be nil"))
+		return b
+	}
+
+	b.opts.Timezone = &TimeZone{loc}
+
+	return b
+}
+
+func (b *ReportBuilder) Campaign(id int) *ReportBuilder {
+	b.opts.Filter.CampaignID = id
+	return b
+}
+
+func (b *ReportBuilder) Variation(id int) *ReportBuilder {
+	b.opts.Filter.VariationID = id
+	return b
+}
+
+func (b *ReportBuilder) Site(id int) *ReportBuilder {
+	b.opts.Filter.SiteID = id
+	return b
+}
+
+func (b *ReportBuilder) Zone(id int) *ReportBuilder {
+	b.opts.Filter.ZoneID = id
+	return b
+}
+
+func (b *ReportBuilder) Category(id int) *ReportBuilder {
+	b.opts.Filter.CategoryID = id
+	return b
+}
+
+func (b *ReportBuilder) Hours(hours ...int) *ReportBuilder {
+	b.opts.Filter.Hour = append(b.opts.Filter.Hour, hours...)
+	return b
+}
+
+func (b *ReportBuilder) ExcludeDeleted() *ReportBuilder {
+	b.opts.Filter.ExcludeDeleted = 1
+	return b
+}
+
+func (b *ReportBuilder) Limit(limit int) *ReportBuilder {
+	b.opts.Limit = limit
+	return b
+}
+
+func (b *ReportBuilder) Offset(offset int) *ReportBuilder {
+	b.opts.Offset = offset
+	return b
+}
+
+func (b *ReportBuilder) Between(from, to time.Time) *ReportBuilder {
+	b.dateRange = func(time.Time) (time.Time, time.Time) {
+		return from, to
+	}
+
+	return b
+}
+
+func (b *ReportBuilder) Last(d time.Duration) *ReportBuilder {
+	days := int(d / Day)
+	if days < 1 || d%Day != 0 {
+		b.errs = append(b.errs, fmt.Errorf("invalid relative range: must be a positive number of days, but got %s", d))
+		return b
+	}
+
+	b.dateRange = func(today time.Time) (time.Time, time.Time) {
+		return today.AddDate(0, 0, -(days - 1)), today
+	}
+
+	return b
+}
+
+func (b *ReportBuilder) Today() *ReportBuilder {
+	return b.Last(Day)
+}
+
+func (b *ReportBuilder) Yesterday() *ReportBuilder {
+	b.dateRange = func(today time.Time) (time.Time, time.Time) {
+		yesterday := today.AddDate(0, 0, -1)
+		return yesterday, yesterday
+	}
+
+	return b
+}
+
+func (b *ReportBuilder) ThisMonth() *ReportBuilder {
+	b.dateRange = func(today time.Time) (time.Time, time.Time) {
+		return today.AddDate(0, 0, 1-today.Day()), today
+	}
+
+	return b
+}
+
+func (b *ReportBuilder) LastMonth() *ReportBuilder {
+	b.dateRange = func(today time.Time) (time.Time, time.Time) {
+		firstOfMonth := today.AddDate(0, 0, 1-today.Day())
+		return firstOfMonth.AddDate(0, -1, 0), firstOfMonth.AddDate(0, 0, -1)
+	}
+
+	return b
+}
+
+func (b *ReportBuilder) Build() (*StatisticsOptions, error) {
+	opts := b.opts
+
+	opts.GroupBy = slices.Clone(b.opts.GroupBy)
+	opts.OrderBy = slices.Clone(b.opts.OrderBy)
+	opts.Filter.Hour = slices.Clone(b.opts.Filter.Hour)
+
+	errs := slices.Clone(b.errs)
+
+	if len(b.metrics) == 0 {
+		errs = append(errs, errors.New("at least one metric is required"))
+	}
+
+	for _, field := range opts.GroupBy {
+		opts.OutputCsvFields = appendField(opts.OutputCsvFields, field)
+	}
+
+	for _, field := range b.metrics {
+		opts.OutputCsvFields = appendField(opts.OutputCsvFields, field)
+	}
+
+	for _, orderBy := range opts.OrderBy {
+		opts.OutputCsvFields = appendField(opts.OutputCsvFields, orderBy.Field)
+	}
+
+	for _, field := range opts.OutputCsvFields {
+		if slices.Contains(FieldsRequireDetailed, field) {
+			opts.Detailed = true
+		}
+	}
+
+	if b.dateRange == nil {
+		errs = append(errs, errors.New("date range must be set"))
+	} else {
+		loc := time.UTC
+		if opts.Timezone != nil {
+			loc = opts.Timezone.Location
+		}
+
+		now := time.Now().In(loc)
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+
+		from, to := b.dateRange(today)
+		opts.Filter.DateFrom = CustomDate{from}
+		opts.Filter.DateTo = CustomDate{to}
+	}
+
+	errs = append(errs, validateStatisticsCSVOptions(&opts))
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	return &opts, nil
+}
+
+func appendField(fields []StatisticsField, field StatisticsField) []StatisticsField {
+	if field == "" || slices.Contains(fields, field) {
+		return fields
+	}
+
+	return append(fields, field)
+}
@@ -0,0 +1,327 @@
+package exoclick
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Schedule struct {
+	Timezone *TimeZone
+	Hours    [7][24]bool
+}
+
+func NewSchedule(loc *time.Location) *Schedule {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	return &Schedule{Timezone: &TimeZone{loc}}
+}
+
+func ParseSchedule(loc *time.Location, specs ...string) (*Schedule, error) {
+	s := NewSchedule(loc)
+
+	var errs []error
+	for _, spec := range specs {
+		if err := s.AddSpec(spec); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s Schedule) String() string {
+	var days []string
+
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		var hours []string
+		for hour := 0; hour < 24; hour++ {
+			if s.Hours[day][hour] {
+				hours = append(hours, strconv.Itoa(hour))
+			}
+		}
+
+		if len(hours) > 0 {
+			days = append(days, day.String()[:3]+":"+strings.Join(hours, ","))
+		}
+	}
+
+	return fmt.Sprintf("exoclick.Schedule{%s %s}", s.location(), strings.Join(days, " "))
+}
+
+func (s *Schedule) location() *time.Location {
+	if s.Timezone == nil || s.Timezone.Location == nil {
+		return time.UTC
+	}
+
+	return s.Timezone.Location
+}
+
+func (s *Schedule) Set(day time.Weekday, hour int, active bool) error {
+	if day < time.Sunday || day > time.Saturday {
+		return fmt.Errorf("invalid day: day must be between Sunday and Saturday, but got %d", day)
+	}
+
+	if hour < 0 || hour > 23 {
+		return fmt.Errorf("invalid hour: hour must be between 0 and 23, but got %d", hour)
+	}
+
+	s.Hours[day][hour] = active
+
+	return nil
+}
+
+func (s *Schedule) AddRange(fromDay, toDay time.Weekday, startHour, endHour int) error {
+	if fromDay < time.Sunday || fromDay > time.Saturday {
+		return fmt.Errorf("invalid start day: day must be between Sunday and Saturday, but got %d", fromDay)
+	}
+
+	if toDay < time.Sunday || toDay > time.Saturday {
+		return fmt.Errorf("invalid end day: day must be between Sunday and Saturday, but got %d", toDay)
+	}
+
+	if startHour < 0 || startHour > 23 {
+		return fmt.Errorf("invalid start hour: hour must be between 0 and 23, but got %d", startHour)
+	}
+
+	if endHour < 0 || endHour > 24 {
+		return fmt.Errorf("invalid end hour: hour must be between 0 and 24, but got %d", endHour)
+	}
+
+	for day := fromDay; ; day = (day + 1) % 7 {
+		if startHour < endHour {
+			for hour := startHour; hour < endHour; hour++ {
+				s.Hours[day][hour] = true
+			}
+		} else {
+			for hour := startHour; hour < 24; hour++ {
+				s.Hours[day][hour] = true
+			}
+
+			for hour := 0; hour < endHour; hour++ {
+				s.Hours[(day+1)%7][hour] = true
+			}
+		}
+
+		if day == toDay {
+			break
+		}
+	}
+
+	return nil
+}
+
+func (s *Schedule) AddSpec(spec string) error {
+	fields := strings.Fields(spec)
+	if len(fields) != 2 {
+		return fmt.Errorf("invalid schedule \"%s\": expected days and hours, e.g. \"Mon-Fri 08:00-22:00\"", spec)
+	}
+
+	startHour, endHour, err := parseHourRange(fields[1])
+	if err != nil {
+		return fmt.Errorf("invalid schedule \"%s\": %w", spec, err)
+	}
+
+	for _, part := range strings.Split(fields[0], ",") {
+		from, to, err := parseDayRange(part)
+		if err != nil {
+			return fmt.Errorf("invalid schedule \"%s\": %w", spec, err)
+		}
+
+		if err := s.AddRange(from, to, startHour, endHour); err != nil {
+			return fmt.Errorf("invalid schedule \"%s\": %w", spec, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Schedule) IsActive(t time.Time) bool {
+	t = t.In(s.location())
+	return s.Hours[t.Weekday()][t.Hour()]
+}
+
+type scheduleDay struct {
+	Day   time.Weekday `json:"day"`
+	Hours []int        `json:"hours"`
+}
+
+type scheduleJSON struct {
+	Timezone *TimeZone     `json:"timezone,omitempty"`
+	Days     []scheduleDay `json:"days"`
+}
+
+func (s *Schedule) MarshalJSON() ([]byte, error) {
+	out := scheduleJSON{Timezone: s.Timezone, Days: []scheduleDay{}}
+
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		var hours []int
+		for hour := 0; hour < 24; hour++ {
+			if s.Hours[day][hour] {
+				hours = append(hours, hour)
+			}
+		}
+
+		if len(hours) > 0 {
+			out.Days = append(out.Days, scheduleDay{Day: day, Hours: hours})
+		}
+	}
+
+	return json.Marshal(out)
+}
+
+func (s *Schedule) UnmarshalJSON(b []byte) error {
+	var in scheduleJSON
+	if err := json.Unmarshal(b, &in); err != nil {
+		return err
+	}
+
+	*s = Schedule{Timezone: in.Timezone}
+
+	for _, day := range in.Days {
+		if day.Day < time.Sunday || day.Day > time.Saturday {
+			return fmt.Errorf("invalid schedule day %d", day.Day)
+		}
+
+		for _, hour := range day.Hours {
+			if hour < 0 || hour > 23 {
+				return fmt.Errorf("invalid schedule hour %d", hour)
+			}
+
+			s.Hours[day.Day][hour] = true
+		}
+	}
+
+	return nil
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+func splitRange(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return r == '-' || r == '–' || r == '—'
+	})
+}
+
+func parseDay(s string) (time.Weekday, error) {
+	s = strings.ToLower(s)
+	if len(s) >= 3 {
+		if day, ok := weekdays[s[:3]]; ok {
+			return day, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unknown day \"%s\"", s)
+}
+
+func parseDayRange(s string) (time.Weekday, time.Weekday, error) {
+	parts := splitRange(s)
+	if len(parts) < 1 || len(parts) > 2 {
+		return 0, 0, fmt.Errorf("invalid day range \"%s\"", s)
+	}
+
+	from, err := parseDay(parts[0])
+	if err != nil {
+		return 0, 0, err
+	}
+
+	to := from
+	if len(parts) == 2 {
+		if to, err = parseDay(parts[1]); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	return from, to, nil
+}
+
+func parseHour(s string) (int, error) {
+	hour, minute, found := strings.Cut(s, ":")
+	if found && minute != "00" {
+		return 0, fmt.Errorf("invalid time \"%s\": only whole hours are supported", s)
+	}
+
+	h, err := strconv.Atoi(hour)
+	if err != nil || h < 0 || h > 24 {
+		return 0, fmt.Errorf("invalid time \"%s\"", s)
+	}
+
+	return h, nil
+}
+
+func parseHourRange(s string) (int, int, error) {
+	parts := splitRange(s)
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("invalid hour range \"%s\"", s)
+	}
+
+	start, err := parseHour(parts[0])
+	if err != nil {
+		return 0, 0, err
+	}
+
+	end, err := parseHour(parts[1])
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if start == 24 {
+		return 0, 0, fmt.Errorf("invalid hour range \"%s\"", s)
+	}
+
+	return start, end, nil
+}
+
+func (c *CampaignsService) GetSchedule(ctx context.Context, campaignID int) (*Schedule, *http.Response, error) {
+	u := fmt.Sprintf("campaigns/%d/dayparting", campaignID)
+
+	req, err := c.client.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	scheduleResponse := struct {
+		Result Schedule `json:"result,omitempty"`
+	}{}
+
+	resp, err := c.client.Do(ctx, req, &scheduleResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &scheduleResponse.Result, resp, nil
+}
+
+func (c *CampaignsService) SetSchedule(ctx context.Context, campaignID int, schedule *Schedule) (*http.Response, error) {
+	u := fmt.Sprintf("campaigns/%d/dayparting", campaignID)
+
+	if schedule == nil {
+		return nil, errors.New("schedule cannot be nil")
+	}
+
+	req, err := c.client.NewRequest(http.MethodPut, u, schedule)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.client.Do(ctx, req, nil)
+}
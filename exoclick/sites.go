@@ -0,0 +1,111 @@
+package exoclick
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+type SitesService service
+
+type Site struct {
+	ID          *int    `json:"id,omitempty"`
+	Name        *string `json:"name,omitempty"`
+	URL         *string `json:"url,omitempty"`
+	Description *string `json:"description,omitempty"`
+	CategoryID  *int    `json:"category,omitempty"`
+	SiteType    *int    `json:"site_type,omitempty"`
+	Status      *int    `json:"status,omitempty"`
+}
+
+func (s Site) String() string {
+	return Stringify(s)
+}
+
+type SiteListOptions struct {
+	Status  int    `url:"status,omitempty"`
+	Search  string `url:"custom_search,omitempty"`
+	OrderBy string `url:"orderBy,omitempty"`
+
+	ListOptions
+}
+
+func (s *SitesService) List(ctx context.Context, opts *SiteListOptions) ([]*Site, *http.Response, error) {
+	u := "sites"
+
+	if opts.OrderBy == "" {
+		opts.OrderBy = "d:id"
+	}
+
+	u, err := addOptions(u, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sitesResponse := struct {
+		Result []*Site `json:"result,omitempty"`
+	}{}
+
+	resp, err := s.client.Do(ctx, req, &sitesResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return sitesResponse.Result, resp, nil
+}
+
+func (s *SitesService) Get(ctx context.Context, id int) (*Site, *http.Response, error) {
+	u := fmt.Sprintf("sites/%d", id)
+
+	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	siteResponse := struct {
+		Result Site `json:"result,omitempty"`
+	}{}
+
+	resp, err := s.client.Do(ctx, req, &siteResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &siteResponse.Result, resp, nil
+}
+
+func (s *SitesService) Create(ctx context.Context, site *Site) (*Site, *http.Response, error) {
+	u := "sites"
+
+	req, err := s.client.NewRequest(http.MethodPost, u, site)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	siteResponse := struct {
+		Result Site `json:"result,omitempty"`
+	}{}
+
+	resp, err := s.client.Do(ctx, req, &siteResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &siteResponse.Result, resp, nil
+}
+
+func (s *SitesService) Update(ctx context.Context, id int, site *Site) (*http.Response, error) {
+	u := fmt.Sprintf("sites/%d", id)
+
+	req, err := s.client.NewRequest(http.MethodPut, u, site)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(ctx, req, nil)
+}
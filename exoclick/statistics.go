@@ -3,11 +3,14 @@ package exoclick
 import (
 	"context"
 	"encoding/csv"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gocarina/gocsv"
@@ -16,27 +19,134 @@ import (
 type StatisticsService service
 
 type Statistic struct {
-	Date             *time.Time `csv:"date,omitempty"`
-	CampaignID       *int       `csv:"campaign_id,omitempty"`
-	VariationID      *int       `csv:"variation_id,omitempty"`
-	SiteID           *int       `csv:"site_id,omitempty"`
-	SiteName         *string    `csv:"site_name,omitempty"`
-	ZoneID           *int       `csv:"zone_id,omitempty"`
-	ZoneName         *string    `csv:"zone_name,omitempty"`
-	CategoryID       *int       `csv:"category_id,omitempty"`
-	Clicks           int        `csv:"clicks"`
-	Impressions      int        `csv:"impressions"`
-	VideoImpressions int        `csv:"video_impressions"`
-	VideoViews       int        `csv:"video_views"`
-	G1               int        `csv:"g1"`
-	G5               int        `csv:"g5"`
-	Cost             float32    `csv:"cost"`
+	Date             *time.Time `csv:"date,omitempty" json:"date,omitempty"`
+	Hour             *int       `csv:"hour,omitempty" json:"hour,omitempty"`
+	CampaignID       *int       `csv:"campaign_id,omitempty" json:"campaign_id,omitempty"`
+	VariationID      *int       `csv:"variation_id,omitempty" json:"variation_id,omitempty"`
+	SiteID           *int       `csv:"site_id,omitempty" json:"site_id,omitempty"`
+	SiteName         *string    `csv:"site_name,omitempty" json:"site_name,omitempty"`
+	ZoneID           *int       `csv:"zone_id,omitempty" json:"zone_id,omitempty"`
+	ZoneName         *string    `csv:"zone_name,omitempty" json:"zone_name,omitempty"`
+	CategoryID       *int       `csv:"category_id,omitempty" json:"category_id,omitempty"`
+	Clicks           int        `csv:"clicks" json:"clicks"`
+	Impressions      int        `csv:"impressions" json:"impressions"`
+	VideoImpressions int        `csv:"video_impressions" json:"video_impressions"`
+	VideoViews       int        `csv:"video_views" json:"video_views"`
+	G1               int        `csv:"g1" json:"g1"`
+	G5               int        `csv:"g5" json:"g5"`
+	Cost             float32    `csv:"cost" json:"cost"`
 }
 
 func (s Statistic) String() string {
 	return Stringify(s)
 }
 
+func (s *Statistic) UnmarshalJSON(b []byte) error {
+	type statistic Statistic
+
+	aux := struct {
+		Date *CustomDate `json:"date,omitempty"`
+		*statistic
+	}{statistic: (*statistic)(s)}
+
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+
+	if aux.Date != nil {
+		s.Date = &aux.Date.Time
+	}
+
+	return nil
+}
+
+func (s *Statistic) Metric(field StatisticsField) (float64, bool) {
+	switch field {
+	case Clicks:
+		return float64(s.Clicks), true
+	case Impressions:
+		return float64(s.Impressions), true
+	case VideoImpressions:
+		return float64(s.VideoImpressions), true
+	case VideoViews:
+		return float64(s.VideoViews), true
+	case G1:
+		return float64(s.G1), true
+	case G5:
+		return float64(s.G5), true
+	case Cost:
+		return float64(s.Cost), true
+	default:
+		return 0, false
+	}
+}
+
+func (s *Statistic) DecimalCost() float64 {
+	f, _ := strconv.ParseFloat(strconv.FormatFloat(float64(s.Cost), 'f', -1, 32), 64)
+	return f
+}
+
+func (s *Statistic) Dimension(field StatisticsField) (string, bool) {
+	formatInt := func(v *int) string {
+		if v == nil {
+			return ""
+		}
+		return strconv.Itoa(*v)
+	}
+
+	switch field {
+	case Date:
+		if s.Date == nil {
+			return "", true
+		}
+		return s.Date.Format("2006-01-02"), true
+	case Hour:
+		return formatInt(s.Hour), true
+	case CampaignID:
+		return formatInt(s.CampaignID), true
+	case VariationID:
+		return formatInt(s.VariationID), true
+	case SiteID:
+		return formatInt(s.SiteID), true
+	case SiteName:
+		if s.SiteName == nil {
+			return "", true
+		}
+		return *s.SiteName, true
+	case ZoneID:
+		return formatInt(s.ZoneID), true
+	case ZoneName:
+		if s.ZoneName == nil {
+			return "", true
+		}
+		return *s.ZoneName, true
+	case CategoryID:
+		return formatInt(s.CategoryID), true
+	default:
+		return "", false
+	}
+}
+
+func (s *Statistic) Key(fields ...StatisticsField) string {
+	parts := make([]string, len(fields))
+
+	for i, field := range fields {
+		parts[i], _ = s.Dimension(field)
+	}
+
+	return strings.Join(parts, "\x1f")
+}
+
+type StatisticsReport struct {
+	Rows  []*Statistic `json:"result"`
+	Total *Statistic   `json:"resultTotal,omitempty"`
+	Size  int          `json:"resultSize"`
+}
+
+func (r StatisticsReport) String() string {
+	return Stringify(r)
+}
+
 var DefaultTimezone = TimeZone{time.UTC}
 
 type StatisticsField string
@@ -92,6 +202,16 @@ var ValidGroupByFields = []StatisticsField{
 	VariationID,
 }
 
+var MetricFields = []StatisticsField{
+	Clicks,
+	Impressions,
+	VideoImpressions,
+	VideoViews,
+	G1,
+	G5,
+	Cost,
+}
+
 var FieldsRequireDetailed = []StatisticsField{
 	SiteName,
 	ZoneName,
@@ -109,40 +229,133 @@ type StatisticsOrderBy struct {
 	Order OrderType       `json:"order,omitempty"`
 }
 
-func (s *StatisticsService) GetStatisticsCSV(ctx context.Context, opts *StatisticsOptions) ([]*Statistic, *http.Response, error) {
+func (o *StatisticsOptions) query() reportQuery {
+	return reportQuery{
+		Timezone:     o.Timezone,
+		DateFrom:     o.Filter.DateFrom,
+		DateTo:       o.Filter.DateTo,
+		Hour:         o.Filter.Hour,
+		GroupBy:      o.GroupBy,
+		OrderBy:      o.OrderBy,
+		OutputFields: o.OutputCsvFields,
+		Detailed:     o.Detailed,
+		ValidGroupBy: ValidGroupByFields,
+	}
+}
+
+func (s *StatisticsService) GetStatistics(ctx context.Context, opts *StatisticsOptions) (*StatisticsReport, *http.Response, error) {
 	u := "statistics/a/global"
 
 	if err := validateStatisticsOptions(opts); err != nil {
 		return nil, nil, err
 	}
 
-	req, err := s.client.NewRequest(http.MethodPost, u, opts)
+	report := new(StatisticsReport)
+
+	resp, err := s.getJSON(ctx, u, opts, opts.query(), report)
 	if err != nil {
-		return nil, nil, err
+		return nil, resp, err
 	}
 
-	req.Header.Set("Accept", "text/csv")
+	localizeStatistics(report.Rows, opts.Timezone)
 
-	resp, err := s.client.BareDo(ctx, req)
+	return report, resp, nil
+}
+
+func (s *StatisticsService) GetStatisticsCSV(ctx context.Context, opts *StatisticsOptions) ([]*Statistic, *http.Response, error) {
+	var statistics []*Statistic
+
+	resp, err := s.StreamStatisticsCSV(ctx, opts, func(statistic *Statistic) error {
+		statistics = append(statistics, statistic)
+		return nil
+	})
 	if err != nil {
 		return nil, resp, err
 	}
 
+	return statistics, resp, nil
+}
+
+func (s *StatisticsService) StreamStatisticsCSV(ctx context.Context, opts *StatisticsOptions, fn func(*Statistic) error) (*http.Response, error) {
+	u := "statistics/a/global"
+
+	if err := validateStatisticsCSVOptions(opts); err != nil {
+		return nil, err
+	}
+
+	return s.streamCSV(ctx, u, opts, opts.query(), func(r io.Reader) error {
+		return decodeCSV(r, opts.OutputCsvFields, func(statistic *Statistic) error {
+			statistic.Date = localizeDate(statistic.Date, opts.Timezone)
+			return fn(statistic)
+		})
+	})
+}
+
+func (s *StatisticsService) getJSON(ctx context.Context, u string, opts any, q reportQuery, v any) (*http.Response, error) {
+	req, err := s.client.NewRequest(http.MethodPost, u, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.do(ctx, req, opts, q)
+	if err != nil {
+		return resp, err
+	}
+
 	defer resp.Body.Close()
 
-	statistics, err := UnmarshalStatisticsCSV(resp.Body, opts.OutputCsvFields)
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+func (s *StatisticsService) streamCSV(ctx context.Context, u string, opts any, q reportQuery, decode func(io.Reader) error) (*http.Response, error) {
+	req, err := s.client.NewRequest(http.MethodPost, u, opts)
 	if err != nil {
-		return nil, resp, err
+		return nil, err
 	}
 
-	return statistics, resp, nil
+	req.Header.Set("Accept", "text/csv")
+
+	resp, err := s.do(ctx, req, opts, q)
+	if err != nil {
+		return resp, err
+	}
+
+	defer resp.Body.Close()
+
+	return resp, decode(resp.Body)
 }
 
 func UnmarshalStatisticsCSV(data io.Reader, outputCSVFields []StatisticsField) ([]*Statistic, error) {
+	var statistics []*Statistic
+
+	err := decodeCSV(data, outputCSVFields, func(statistic *Statistic) error {
+		statistics = append(statistics, statistic)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return statistics, nil
+}
+
+func decodeCSV[T any](data io.Reader, outputCSVFields []StatisticsField, fn func(*T) error) error {
+	if len(outputCSVFields) == 0 {
+		return errors.New("output csv fields must be set")
+	}
+
+	var headerErr error
+
 	headerNormalizer := func(headers []string) []string {
 		if len(headers) != len(outputCSVFields) {
-			fmt.Printf("invalid header count, expected: %d, got: %d", len(outputCSVFields), len(headers))
-			return nil
+			headerErr = fmt.Errorf("invalid header count, expected: %d, got: %d", len(outputCSVFields), len(headers))
+			return headers
 		}
 
 		normalizedHeaders := make([]string, len(headers))
@@ -157,17 +370,21 @@ func UnmarshalStatisticsCSV(data io.Reader, outputCSVFields []StatisticsField) (
 	r := csv.NewReader(data)
 	r.FieldsPerRecord = len(outputCSVFields)
 
-	u, err := gocsv.NewUnmarshaller(r, Statistic{})
+	var zero T
+
+	u, err := gocsv.NewUnmarshaller(r, zero)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	err = u.RenormalizeHeaders(headerNormalizer)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	var statistics []*Statistic
+	if headerErr != nil {
+		return headerErr
+	}
 
 	for {
 		obj, err := u.Read()
@@ -175,78 +392,113 @@ func UnmarshalStatisticsCSV(data io.Reader, outputCSVFields []StatisticsField) (
 			if err == io.EOF {
 				break
 			} else {
-				return nil, err
+				return err
 			}
 		}
 
-		if s, ok := obj.(Statistic); ok {
-			statistics = append(statistics, &s)
-		} else {
-			return nil, errors.New("failed to parse type")
+		row, ok := obj.(T)
+		if !ok {
+			return errors.New("failed to parse type")
+		}
+
+		if err := fn(&row); err != nil {
+			return err
 		}
 	}
 
-	return statistics, nil
+	return nil
 }
 
 func validateStatisticsOptions(opts *StatisticsOptions) error {
-	if opts.Filter.DateFrom.Time.After(opts.Filter.DateTo.Time) {
-		return errors.New("date from must be before or equal to date to")
+	return opts.query().validate()
+}
+
+func validateStatisticsCSVOptions(opts *StatisticsOptions) error {
+	return opts.query().validateCSV()
+}
+
+type reportQuery struct {
+	Timezone     *TimeZone
+	DateFrom     CustomDate
+	DateTo       CustomDate
+	Hour         []int
+	GroupBy      []StatisticsField
+	OrderBy      []StatisticsOrderBy
+	OutputFields []StatisticsField
+	Detailed     bool
+	ValidGroupBy []StatisticsField
+}
+
+func (q reportQuery) validate() error {
+	var errs []error
+
+	if q.DateFrom.Time.After(q.DateTo.Time) {
+		errs = append(errs, errors.New("date from must be before or equal to date to"))
 	}
 
-	if len(opts.Filter.Hour) > 0 && opts.Timezone == nil {
-		return errors.New("timezone must be set if filter hour is set")
+	if len(q.Hour) > 0 && q.Timezone == nil {
+		errs = append(errs, errors.New("timezone must be set if filter hour is set"))
 	}
 
-	for _, hour := range opts.Filter.Hour {
+	for _, hour := range q.Hour {
 		if hour < 0 || hour > 23 {
-			return fmt.Errorf("invalid hour: hour must be between 0 and 23, but got %d", hour)
+			errs = append(errs, fmt.Errorf("invalid hour: hour must be between 0 and 23, but got %d", hour))
 		}
 	}
 
-	if len(opts.GroupBy) > 4 {
-		return fmt.Errorf("invalid group by: maximum of 4 fields allowed, but got %d", len(opts.GroupBy))
-	}
-
-	if len(opts.OrderBy) > 2 {
-		return fmt.Errorf("invalid order by: maximum of 2 fields allowed, but got %d", len(opts.OrderBy))
+	if len(q.GroupBy) > 4 {
+		errs = append(errs, fmt.Errorf("invalid group by: maximum of 4 fields allowed, but got %d", len(q.GroupBy)))
 	}
 
-	if len(opts.OutputCsvFields) == 0 {
-		return fmt.Errorf("invalid output csv fields: minimum of 1 fields required, but got %d", len(opts.OutputCsvFields))
+	if len(q.OrderBy) > 2 {
+		errs = append(errs, fmt.Errorf("invalid order by: maximum of 2 fields allowed, but got %d", len(q.OrderBy)))
 	}
 
-	if !opts.Detailed {
-		for _, outputField := range opts.OutputCsvFields {
+	if !q.Detailed {
+		for _, outputField := range q.OutputFields {
 			if slices.Contains(FieldsRequireDetailed, outputField) {
-				return fmt.Errorf("\"%s\" field requires detailed enabled", outputField)
+				errs = append(errs, fmt.Errorf("\"%s\" field requires detailed enabled", outputField))
 			}
 		}
 	}
 
-	for _, groupBy := range opts.GroupBy {
-		if !slices.Contains(ValidGroupByFields, groupBy) {
-			return fmt.Errorf("invalid group by field: \"%s\"", groupBy)
-		}
-
-		if !slices.Contains(opts.OutputCsvFields, groupBy) {
-			return fmt.Errorf("invalid output csv fields: must contain all group by fields, but \"%s\" is missing", groupBy)
+	for _, groupBy := range q.GroupBy {
+		if !slices.Contains(q.ValidGroupBy, groupBy) {
+			errs = append(errs, fmt.Errorf("invalid group by field: \"%s\"", groupBy))
 		}
 	}
 
-	for _, orderBy := range opts.OrderBy {
+	for _, orderBy := range q.OrderBy {
 		if orderBy.Field == "" {
-			return errors.New("missing order by Field")
+			errs = append(errs, errors.New("missing order by Field"))
 		}
 
 		if orderBy.Order == "" {
-			return errors.New("missing order by Order")
+			errs = append(errs, errors.New("missing order by Order"))
 		}
+	}
+
+	return errors.Join(errs...)
+}
 
-		if !slices.Contains(opts.OutputCsvFields, orderBy.Field) {
-			return fmt.Errorf("invalid output csv fields: must contain all order by fields, but \"%s\" is missing", orderBy.Field)
+func (q reportQuery) validateCSV() error {
+	errs := []error{q.validate()}
+
+	if len(q.OutputFields) == 0 {
+		errs = append(errs, fmt.Errorf("invalid output csv fields: minimum of 1 fields required, but got %d", len(q.OutputFields)))
+	}
+
+	for _, groupBy := range q.GroupBy {
+		if !slices.Contains(q.OutputFields, groupBy) {
+			errs = append(errs, fmt.Errorf("invalid output csv fields: must contain all group by fields, but \"%s\" is missing", groupBy))
 		}
 	}
 
-	return nil
+	for _, orderBy := range q.OrderBy {
+		if orderBy.Field != "" && !slices.Contains(q.OutputFields, orderBy.Field) {
+			errs = append(errs, fmt.Errorf("invalid output csv fields: must contain all order by fields, but \"%s\" is missing", orderBy.Field))
+		}
+	}
+
+	return errors.Join(errs...)
 }
@@ -4,9 +4,13 @@ import (
 	"bytes"
 	"fmt"
 	"reflect"
+	"time"
 )
 
-var dateType = reflect.TypeOf(CustomDate{})
+var (
+	dateType = reflect.TypeOf(CustomDate{})
+	timeType = reflect.TypeOf(time.Time{})
+)
 
 func Stringify(message interface{}) string {
 	var buf bytes.Buffer
@@ -43,6 +47,11 @@ func stringifyValue(w *bytes.Buffer, val reflect.Value) {
 			w.WriteString(v.Type().String())
 		}
 
+		if v.Type() == timeType {
+			fmt.Fprintf(w, "{%s}", v.Interface())
+			return
+		}
+
 		if v.Type() == dateType {
 			fmt.Fprintf(w, "{%s}", v.Interface())
 			return
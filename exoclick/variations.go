@@ -1,5 +1,11 @@
 package exoclick
 
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
 type Variation struct {
 	ID               int     `json:"idvariation"`
 	Name             string  `json:"name"`
@@ -26,3 +32,110 @@ type Variation struct {
 func (v Variation) String() string {
 	return Stringify(v)
 }
+
+type VariationData struct {
+	Name           *string `json:"name,omitempty"`
+	Description    *string `json:"description,omitempty"`
+	Url            *string `json:"url,omitempty"`
+	ImgUrl         *string `json:"imgurl,omitempty"`
+	UrlDescription *string `json:"durl,omitempty"`
+	OfferID        *int    `json:"offer_id,omitempty"`
+	FileID         *int    `json:"idvariations_file,omitempty"`
+	UrlID          *int    `json:"idvariations_url,omitempty"`
+	HtmlID         *int    `json:"idvariations_html,omitempty"`
+	IframeUrlID    *int    `json:"idvariations_iframe_url,omitempty"`
+	IsExplicit     *bool   `json:"is_explicit,omitempty"`
+	Share          *int    `json:"share,omitempty"`
+}
+
+func (v VariationData) String() string {
+	return Stringify(v)
+}
+
+func (v Variation) Data() *VariationData {
+	data := &VariationData{
+		OfferID:     v.OfferID,
+		HtmlID:      v.HtmlID,
+		IframeUrlID: v.IframeUrlID,
+	}
+
+	for _, f := range []struct {
+		dst **string
+		v   string
+	}{
+		{&data.Name, v.Name},
+		{&data.Description, v.Description},
+		{&data.Url, v.Url},
+		{&data.ImgUrl, v.ImgUrl},
+		{&data.UrlDescription, v.UrlDescription},
+	} {
+		if f.v != "" {
+			value := f.v
+			*f.dst = &value
+		}
+	}
+
+	for _, f := range []struct {
+		dst **int
+		v   int
+	}{
+		{&data.FileID, v.FileID},
+		{&data.UrlID, v.UrlID},
+		{&data.Share, v.Share},
+	} {
+		if f.v != 0 {
+			value := f.v
+			*f.dst = &value
+		}
+	}
+
+	if v.IsExplicit {
+		explicit := true
+		data.IsExplicit = &explicit
+	}
+
+	return data
+}
+
+func (c *CampaignsService) CreateVariation(ctx context.Context, campaignID int, variation *VariationData) (*Variation, *http.Response, error) {
+	u := fmt.Sprintf("campaigns/%d/variation", campaignID)
+
+	if variation.Url != nil {
+		if err := ValidateTrackingURL(*variation.Url); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	req, err := c.client.NewRequest(http.MethodPost, u, variation)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	variationResponse := struct {
+		Result Variation `json:"result,omitempty"`
+	}{}
+
+	resp, err := c.client.Do(ctx, req, &variationResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &variationResponse.Result, resp, nil
+}
+
+func (c *CampaignsService) UpdateVariation(ctx context.Context, campaignID int, variationID int, variation *VariationData) (*http.Response, error) {
+	u := fmt.Sprintf("campaigns/%d/variation/%d", campaignID, variationID)
+
+	if variation.Url != nil {
+		if err := ValidateTrackingURL(*variation.Url); err != nil {
+			return nil, err
+		}
+	}
+
+	req, err := c.client.NewRequest(http.MethodPut, u, variation)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.client.Do(ctx, req, nil)
+}
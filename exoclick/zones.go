@@ -0,0 +1,151 @@
+package exoclick
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+type ZonesService service
+
+type Zone struct {
+	ID          *int        `json:"id,omitempty"`
+	SiteID      *int        `json:"idsite,omitempty"`
+	Name        *string     `json:"name,omitempty"`
+	AdFormat    *string     `json:"ad_format,omitempty"`
+	Size        *string     `json:"size,omitempty"`
+	FloorPrice  *float64    `json:"floor_price,omitempty"`
+	CategoryID  *int        `json:"category,omitempty"`
+	Status      *int        `json:"status,omitempty"`
+	DateCreated *CustomDate `json:"date_created,omitempty"`
+}
+
+func (z Zone) String() string {
+	return Stringify(z)
+}
+
+type ZoneListOptions struct {
+	SiteID   int    `url:"idsite,omitempty"`
+	Status   int    `url:"status,omitempty"`
+	AdFormat string `url:"ad_format,omitempty"`
+	OrderBy  string `url:"orderBy,omitempty"`
+
+	ListOptions
+}
+
+type AdCode struct {
+	ZoneID *int    `json:"idzone,omitempty"`
+	Code   *string `json:"code,omitempty"`
+}
+
+func (a AdCode) String() string {
+	return Stringify(a)
+}
+
+func (z *ZonesService) List(ctx context.Context, opts *ZoneListOptions) ([]*Zone, *http.Response, error) {
+	u := "zones"
+
+	if opts.OrderBy == "" {
+		opts.OrderBy = "d:id"
+	}
+
+	u, err := addOptions(u, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := z.client.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	zonesResponse := struct {
+		Result []*Zone `json:"result,omitempty"`
+	}{}
+
+	resp, err := z.client.Do(ctx, req, &zonesResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return zonesResponse.Result, resp, nil
+}
+
+func (z *ZonesService) Get(ctx context.Context, id int) (*Zone, *http.Response, error) {
+	u := fmt.Sprintf("zones/%d", id)
+
+	req, err := z.client.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	zoneResponse := struct {
+		Result Zone `json:"result,omitempty"`
+	}{}
+
+	resp, err := z.client.Do(ctx, req, &zoneResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &zoneResponse.Result, resp, nil
+}
+
+func (z *ZonesService) Create(ctx context.Context, zone *Zone) (*Zone, *http.Response, error) {
+	u := "zones"
+
+	req, err := z.client.NewRequest(http.MethodPost, u, zone)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	zoneResponse := struct {
+		Result Zone `json:"result,omitempty"`
+	}{}
+
+	resp, err := z.client.Do(ctx, req, &zoneResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &zoneResponse.Result, resp, nil
+}
+
+func (z *ZonesService) Update(ctx context.Context, id int, zone *Zone) (*http.Response, error) {
+	u := fmt.Sprintf("zones/%d", id)
+
+	req, err := z.client.NewRequest(http.MethodPut, u, zone)
+	if err != nil {
+		return nil, err
+	}
+
+	return z.client.Do(ctx, req, nil)
+}
+
+func (z *ZonesService) SetFloorPrice(ctx context.Context, id int, price float64) (*http.Response, error) {
+	return z.Update(ctx, id, &Zone{FloorPrice: &price})
+}
+
+func (z *ZonesService) SetStatus(ctx context.Context, id int, status int) (*http.Response, error) {
+	return z.Update(ctx, id, &Zone{Status: &status})
+}
+
+func (z *ZonesService) GetAdCode(ctx context.Context, id int) (*AdCode, *http.Response, error) {
+	u := fmt.Sprintf("zones/%d/code", id)
+
+	req, err := z.client.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	adCodeResponse := struct {
+		Result AdCode `json:"result,omitempty"`
+	}{}
+
+	resp, err := z.client.Do(ctx, req, &adCodeResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &adCodeResponse.Result, resp, nil
+}
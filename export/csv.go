@@ -0,0 +1,70 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+
+	"github.com/adam-szerdahelyi/go-exoclick/exoclick"
+)
+
+type CSVWriter struct {
+	closer
+	csv    *csv.Writer
+	fields []exoclick.StatisticsField
+	header bool
+}
+
+func NewCSVWriter(w io.Writer, opts Options) (*CSVWriter, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
+	return &CSVWriter{csv: csv.NewWriter(w), fields: opts.Fields}, nil
+}
+
+func (w *CSVWriter) writeHeader() error {
+	if w.header {
+		return nil
+	}
+
+	w.header = true
+
+	header := make([]string, len(w.fields))
+	for i, field := range w.fields {
+		header[i] = string(field)
+	}
+
+	return w.csv.Write(header)
+}
+
+func (w *CSVWriter) Write(s *exoclick.Statistic) error {
+	if err := w.check(); err != nil {
+		return err
+	}
+
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+
+	record := make([]string, len(w.fields))
+	for i, field := range w.fields {
+		record[i] = cellOf(s, field).String()
+	}
+
+	return w.csv.Write(record)
+}
+
+func (w *CSVWriter) Close() error {
+	if err := w.check(); err != nil {
+		return err
+	}
+
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+
+	w.closed = true
+	w.csv.Flush()
+
+	return w.csv.Error()
+}
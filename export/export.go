@@ -0,0 +1,181 @@
+package export
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/adam-szerdahelyi/go-exoclick/exoclick"
+)
+
+type Writer interface {
+	Write(s *exoclick.Statistic) error
+	Close() error
+}
+
+type Options struct {
+	Fields []exoclick.StatisticsField
+}
+
+func (o Options) validate() error {
+	if len(o.Fields) == 0 {
+		return errors.New("at least one field is required")
+	}
+
+	for _, field := range o.Fields {
+		if _, err := kindOf(field); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func WriteAll(w Writer, rows []*exoclick.Statistic) error {
+	for _, row := range rows {
+		if err := w.Write(row); err != nil {
+			return err
+		}
+	}
+
+	return w.Close()
+}
+
+type kind uint8
+
+const (
+	intKind kind = iota
+	floatKind
+	stringKind
+	dateKind
+)
+
+type cell struct {
+	kind  kind
+	null  bool
+	int   int64
+	float float64
+	str   string
+	date  time.Time
+}
+
+func (c cell) String() string {
+	if c.null {
+		return ""
+	}
+
+	switch c.kind {
+	case intKind:
+		return strconv.FormatInt(c.int, 10)
+	case floatKind:
+		return strconv.FormatFloat(c.float, 'f', -1, 64)
+	case dateKind:
+		return c.date.Format("2006-01-02")
+	default:
+		return c.str
+	}
+}
+
+func (c cell) Value() any {
+	if c.null {
+		return nil
+	}
+
+	switch c.kind {
+	case intKind:
+		return c.int
+	case floatKind:
+		return c.float
+	case dateKind:
+		return c.date.Format("2006-01-02")
+	default:
+		return c.str
+	}
+}
+
+func kindOf(field exoclick.StatisticsField) (kind, error) {
+	switch field {
+	case exoclick.Date:
+		return dateKind, nil
+	case exoclick.Hour, exoclick.CampaignID, exoclick.VariationID, exoclick.SiteID, exoclick.ZoneID, exoclick.CategoryID,
+		exoclick.Clicks, exoclick.Impressions, exoclick.VideoImpressions, exoclick.VideoViews, exoclick.G1, exoclick.G5:
+		return intKind, nil
+	case exoclick.SiteName, exoclick.ZoneName:
+		return stringKind, nil
+	case exoclick.Cost:
+		return floatKind, nil
+	default:
+		return 0, fmt.Errorf("unsupported export field: \"%s\"", field)
+	}
+}
+
+func nullable(field exoclick.StatisticsField) bool {
+	_, ok := new(exoclick.Statistic).Metric(field)
+	return !ok
+}
+
+func cellOf(s *exoclick.Statistic, field exoclick.StatisticsField) cell {
+	k, _ := kindOf(field)
+	c := cell{kind: k}
+
+	intPtr := func(v *int) {
+		if v == nil {
+			c.null = true
+			return
+		}
+		c.int = int64(*v)
+	}
+
+	strPtr := func(v *string) {
+		if v == nil {
+			c.null = true
+			return
+		}
+		c.str = *v
+	}
+
+	switch field {
+	case exoclick.Date:
+		if s.Date == nil {
+			c.null = true
+		} else {
+			c.date = time.Date(s.Date.Year(), s.Date.Month(), s.Date.Day(), 0, 0, 0, 0, time.UTC)
+		}
+	case exoclick.Hour:
+		intPtr(s.Hour)
+	case exoclick.CampaignID:
+		intPtr(s.CampaignID)
+	case exoclick.VariationID:
+		intPtr(s.VariationID)
+	case exoclick.SiteID:
+		intPtr(s.SiteID)
+	case exoclick.SiteName:
+		strPtr(s.SiteName)
+	case exoclick.ZoneID:
+		intPtr(s.ZoneID)
+	case exoclick.ZoneName:
+		strPtr(s.ZoneName)
+	case exoclick.CategoryID:
+		intPtr(s.CategoryID)
+	case exoclick.Cost:
+		c.float = s.DecimalCost()
+	default:
+		v, _ := s.Metric(field)
+		c.int = int64(v)
+	}
+
+	return c
+}
+
+type closer struct {
+	closed bool
+}
+
+func (c *closer) check() error {
+	if c.closed {
+		return errors.New("writer is closed")
+	}
+
+	return nil
+}
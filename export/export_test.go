@@ -0,0 +1,165 @@
+package export
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/adam-szerdahelyi/go-exoclick/exoclick"
+	"github.com/xitongsys/parquet-go/reader"
+	"github.com/xitongsys/parquet-go/source"
+	"github.com/xuri/excelize/v2"
+)
+
+var testFields = []exoclick.StatisticsField{
+	exoclick.Date,
+	exoclick.CampaignID,
+	exoclick.ZoneName,
+	exoclick.Impressions,
+	exoclick.Cost,
+}
+
+func testRows() []*exoclick.Statistic {
+	date := func(day int) *time.Time {
+		t := time.Date(2024, time.March, day, 0, 0, 0, 0, time.UTC)
+		return &t
+	}
+
+	id := func(v int) *int { return &v }
+	zone := "zone <a> & b"
+
+	return []*exoclick.Statistic{
+		{Date: date(1), CampaignID: id(10), ZoneName: &zone, Impressions: 1000, Cost: 0.1},
+		{Date: date(2), CampaignID: id(20), Impressions: 0, Cost: 12.35},
+		{Date: date(3), Impressions: 2500, Cost: 0},
+	}
+}
+
+type parquetBuffer struct {
+	*bytes.Reader
+	data []byte
+}
+
+func (b *parquetBuffer) Open(string) (source.ParquetFile, error) {
+	return &parquetBuffer{Reader: bytes.NewReader(b.data), data: b.data}, nil
+}
+
+func (b *parquetBuffer) Create(string) (source.ParquetFile, error) {
+	return nil, io.ErrUnexpectedEOF
+}
+
+func (b *parquetBuffer) Write([]byte) (int, error) {
+	return 0, io.ErrShortWrite
+}
+
+func (b *parquetBuffer) Close() error {
+	return nil
+}
+
+func TestParquetWriterRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+
+	w, err := NewParquetWriter(&buf, Options{Fields: testFields})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := WriteAll(w, testRows()); err != nil {
+		t.Fatal(err)
+	}
+
+	file := &parquetBuffer{Reader: bytes.NewReader(buf.Bytes()), data: buf.Bytes()}
+
+	pr, err := reader.NewParquetColumnReader(file, 1)
+	if err != nil {
+		t.Fatalf("reading parquet file: %v", err)
+	}
+	defer pr.ReadStop()
+
+	if got := pr.GetNumRows(); got != 3 {
+		t.Fatalf("expected 3 rows, but got %d", got)
+	}
+
+	want := [][]any{
+		{int32(19783), int32(19784), int32(19785)},
+		{int64(10), int64(20), nil},
+		{"zone <a> & b", nil, nil},
+		{int64(1000), int64(0), int64(2500)},
+		{0.1, 12.35, 0.0},
+	}
+
+	for i, path := range pr.SchemaHandler.ValueColumns {
+		values, _, _, err := pr.ReadColumnByPath(path, 3)
+		if err != nil {
+			t.Fatalf("reading column %s: %v", path, err)
+		}
+
+		if len(values) != len(want[i]) {
+			t.Fatalf("column %s: expected %d values, but got %d", path, len(want[i]), len(values))
+		}
+
+		for j, v := range values {
+			if v != want[i][j] {
+				t.Errorf("column %s row %d: expected %#v, but got %#v", path, j, want[i][j], v)
+			}
+		}
+	}
+}
+
+func TestXLSXWriterRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+
+	w, err := NewXLSXWriter(&buf, Options{Fields: testFields})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := WriteAll(w, testRows()); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := excelize.OpenReader(&buf)
+	if err != nil {
+		t.Fatalf("reading xlsx file: %v", err)
+	}
+	defer f.Close()
+
+	rows, err := f.GetRows("Statistics")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := [][]string{
+		{"date", "campaign_id", "zone_name", "impressions", "cost"},
+		{"2024-03-01", "10", "zone <a> & b", "1000", "0.1"},
+		{"2024-03-02", "20", "", "0", "12.35"},
+		{"2024-03-03", "", "", "2500", "0"},
+	}
+
+	if len(rows) != len(want) {
+		t.Fatalf("expected %d rows, but got %d", len(want), len(rows))
+	}
+
+	for i := range want {
+		for j := range want[i] {
+			var got string
+			if j < len(rows[i]) {
+				got = rows[i][j]
+			}
+
+			if got != want[i][j] {
+				t.Errorf("cell %d,%d: expected %q, but got %q", i, j, want[i][j], got)
+			}
+		}
+	}
+
+	date, err := f.GetCellValue("Statistics", "A2", excelize.Options{RawCellValue: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if date != "45352" {
+		t.Errorf("expected date serial 45352, but got %q", date)
+	}
+}
@@ -0,0 +1,79 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+
+	"github.com/adam-szerdahelyi/go-exoclick/exoclick"
+)
+
+type JSONLinesWriter struct {
+	closer
+	buf    *bufio.Writer
+	fields []exoclick.StatisticsField
+}
+
+func NewJSONLinesWriter(w io.Writer, opts Options) (*JSONLinesWriter, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
+	return &JSONLinesWriter{buf: bufio.NewWriter(w), fields: opts.Fields}, nil
+}
+
+func (w *JSONLinesWriter) Write(s *exoclick.Statistic) error {
+	if err := w.check(); err != nil {
+		return err
+	}
+
+	var line bytes.Buffer
+
+	line.WriteByte('{')
+
+	for i, field := range w.fields {
+		if i > 0 {
+			line.WriteByte(',')
+		}
+
+		if err := encodeJSON(&line, string(field)); err != nil {
+			return err
+		}
+
+		line.WriteByte(':')
+
+		if err := encodeJSON(&line, cellOf(s, field).Value()); err != nil {
+			return err
+		}
+	}
+
+	line.WriteString("}\n")
+
+	_, err := w.buf.Write(line.Bytes())
+
+	return err
+}
+
+func (w *JSONLinesWriter) Close() error {
+	if err := w.check(); err != nil {
+		return err
+	}
+
+	w.closed = true
+
+	return w.buf.Flush()
+}
+
+func encodeJSON(buf *bytes.Buffer, v any) error {
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+
+	buf.Truncate(buf.Len() - 1)
+
+	return nil
+}
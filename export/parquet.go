@@ -0,0 +1,93 @@
+package export
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/adam-szerdahelyi/go-exoclick/exoclick"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+type ParquetWriter struct {
+	closer
+	pw     *writer.CSVWriter
+	fields []exoclick.StatisticsField
+}
+
+func NewParquetWriter(w io.Writer, opts Options) (*ParquetWriter, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
+	schema := make([]string, len(opts.Fields))
+	for i, field := range opts.Fields {
+		schema[i] = parquetColumn(field)
+	}
+
+	pw, err := writer.NewCSVWriterFromWriter(schema, w, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ParquetWriter{pw: pw, fields: opts.Fields}, nil
+}
+
+func (w *ParquetWriter) Write(s *exoclick.Statistic) error {
+	if err := w.check(); err != nil {
+		return err
+	}
+
+	record := make([]any, len(w.fields))
+
+	for i, field := range w.fields {
+		c := cellOf(s, field)
+		if c.null {
+			continue
+		}
+
+		switch c.kind {
+		case dateKind:
+			record[i] = int32(c.date.Unix() / 86400)
+		case floatKind:
+			record[i] = c.float
+		case stringKind:
+			record[i] = c.str
+		default:
+			record[i] = c.int
+		}
+	}
+
+	return w.pw.Write(record)
+}
+
+func (w *ParquetWriter) Close() error {
+	if err := w.check(); err != nil {
+		return err
+	}
+
+	w.closed = true
+
+	return w.pw.WriteStop()
+}
+
+func parquetColumn(field exoclick.StatisticsField) string {
+	repetition := "REQUIRED"
+	if nullable(field) {
+		repetition = "OPTIONAL"
+	}
+
+	var typ string
+
+	switch k, _ := kindOf(field); k {
+	case dateKind:
+		typ = "type=INT32, convertedtype=DATE"
+	case floatKind:
+		typ = "type=DOUBLE"
+	case stringKind:
+		typ = "type=BYTE_ARRAY, convertedtype=UTF8"
+	default:
+		typ = "type=INT64"
+	}
+
+	return fmt.Sprintf("name=%s, %s, repetitiontype=%s", field, typ, repetition)
+}
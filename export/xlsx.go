@@ -0,0 +1,109 @@
+package export
+
+import (
+	"io"
+
+	"github.com/adam-szerdahelyi/go-exoclick/exoclick"
+	"github.com/xuri/excelize/v2"
+)
+
+const xlsxSheet = "Statistics"
+
+type XLSXWriter struct {
+	closer
+	w         io.Writer
+	file      *excelize.File
+	sheet     *excelize.StreamWriter
+	fields    []exoclick.StatisticsField
+	row       int
+	dateStyle int
+}
+
+func NewXLSXWriter(w io.Writer, opts Options) (*XLSXWriter, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
+	file := excelize.NewFile()
+
+	if err := file.SetSheetName(file.GetSheetName(0), xlsxSheet); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	format := "yyyy-mm-dd"
+
+	dateStyle, err := file.NewStyle(&excelize.Style{CustomNumFmt: &format})
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	sheet, err := file.NewStreamWriter(xlsxSheet)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	xw := &XLSXWriter{w: w, file: file, sheet: sheet, fields: opts.Fields, dateStyle: dateStyle}
+
+	header := make([]any, len(opts.Fields))
+	for i, field := range opts.Fields {
+		header[i] = string(field)
+	}
+
+	if err := xw.writeRow(header); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return xw, nil
+}
+
+func (w *XLSXWriter) writeRow(values []any) error {
+	w.row++
+
+	cell, err := excelize.CoordinatesToCellName(1, w.row)
+	if err != nil {
+		return err
+	}
+
+	return w.sheet.SetRow(cell, values)
+}
+
+func (w *XLSXWriter) Write(s *exoclick.Statistic) error {
+	if err := w.check(); err != nil {
+		return err
+	}
+
+	values := make([]any, len(w.fields))
+
+	for i, field := range w.fields {
+		c := cellOf(s, field)
+
+		switch {
+		case c.null:
+		case c.kind == dateKind:
+			values[i] = excelize.Cell{StyleID: w.dateStyle, Value: c.date}
+		default:
+			values[i] = c.Value()
+		}
+	}
+
+	return w.writeRow(values)
+}
+
+func (w *XLSXWriter) Close() error {
+	if err := w.check(); err != nil {
+		return err
+	}
+
+	w.closed = true
+	defer w.file.Close()
+
+	if err := w.sheet.Flush(); err != nil {
+		return err
+	}
+
+	return w.file.Write(w.w)
+}
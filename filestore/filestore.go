@@ -0,0 +1,60 @@
+package filestore
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+type JSON[V any] struct {
+	Path string
+}
+
+func NewJSON[V any](path string) *JSON[V] {
+	return &JSON[V]{Path: path}
+}
+
+func (s *JSON[V]) Load() (map[string]V, error) {
+	b, err := os.ReadFile(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]V{}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]V)
+	if err := json.Unmarshal(b, &values); err != nil {
+		return nil, fmt.Errorf("decoding %s: %w", s.Path, err)
+	}
+
+	return values, nil
+}
+
+func (s *JSON[V]) Save(values map[string]V) error {
+	b, err := json.MarshalIndent(values, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.Path), filepath.Base(s.Path)+".*")
+	if err != nil {
+		return err
+	}
+
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	return os.Rename(tmp.Name(), s.Path)
+}
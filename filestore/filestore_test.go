@@ -0,0 +1,46 @@
+package filestore
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	store := NewJSON[time.Time](filepath.Join(t.TempDir(), "state.json"))
+
+	values, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if values == nil || len(values) != 0 {
+		t.Fatalf("expected an empty map for a missing file, but got %v", values)
+	}
+
+	want := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	values["a"] = want
+
+	if err := store.Save(values); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !loaded["a"].Equal(want) || len(loaded) != 1 {
+		t.Errorf("expected %v, but got %v", values, loaded)
+	}
+
+	entries, err := os.ReadDir(filepath.Dir(store.Path))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 1 {
+		t.Errorf("expected only the state file, but found %d entries", len(entries))
+	}
+}
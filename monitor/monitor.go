@@ -0,0 +1,429 @@
+package monitor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/adam-szerdahelyi/go-exoclick/analysis"
+	"github.com/adam-szerdahelyi/go-exoclick/exoclick"
+)
+
+const (
+	DefaultInterval              = 15 * time.Minute
+	DefaultBaselineDays          = 14
+	DefaultThreshold             = 3.0
+	DefaultMinBaseline           = 7
+	DefaultMinImpressions        = 1000
+	DefaultMinExpectedConversion = 1.0
+)
+
+type Kind string
+
+const (
+	SpendSpike      Kind = "spend_spike"
+	ImpressionDrop  Kind = "impression_drop"
+	CTRCollapse     Kind = "ctr_collapse"
+	ZeroConversions Kind = "zero_conversions"
+)
+
+type Fetcher interface {
+	GetStatisticsCSV(ctx context.Context, opts *exoclick.StatisticsOptions) ([]*exoclick.Statistic, *http.Response, error)
+}
+
+type Options struct {
+	Campaigns             []int
+	BaselineDays          int
+	Seasonal              bool
+	Threshold             float64
+	MinBaseline           int
+	MinImpressions        int
+	MinExpectedConversion float64
+	Goal                  exoclick.StatisticsField
+	Interval              time.Duration
+	Timezone              *time.Location
+	Dedupe                DedupeStore
+	OnError               func(error)
+}
+
+type Alert struct {
+	Time       time.Time `json:"time"`
+	Kind       Kind      `json:"kind"`
+	CampaignID int       `json:"campaign_id"`
+	Hour       time.Time `json:"hour"`
+	Value      float64   `json:"value"`
+	Baseline   float64   `json:"baseline"`
+	StdDev     float64   `json:"std_dev"`
+	ZScore     float64   `json:"z_score"`
+	Message    string    `json:"message"`
+}
+
+func alertKey(alert Alert) string {
+	return fmt.Sprintf("%s:%d:%d", alert.Kind, alert.CampaignID, alert.Hour.Unix())
+}
+
+type Monitor struct {
+	fetcher Fetcher
+	sinks   []AlertSink
+	opts    Options
+	goal    analysis.Metric
+	now     func() time.Time
+
+	mu   sync.Mutex
+	sent map[string]time.Time
+}
+
+func New(fetcher Fetcher, opts Options, sinks ...AlertSink) (*Monitor, error) {
+	if opts.BaselineDays == 0 {
+		opts.BaselineDays = DefaultBaselineDays
+	}
+
+	if opts.Threshold == 0 {
+		opts.Threshold = DefaultThreshold
+	}
+
+	if opts.MinBaseline == 0 {
+		opts.MinBaseline = DefaultMinBaseline
+	}
+
+	if opts.MinImpressions == 0 {
+		opts.MinImpressions = DefaultMinImpressions
+	}
+
+	if opts.MinExpectedConversion == 0 {
+		opts.MinExpectedConversion = DefaultMinExpectedConversion
+	}
+
+	if opts.Goal == "" {
+		opts.Goal = exoclick.G1
+	}
+
+	if opts.Interval == 0 {
+		opts.Interval = DefaultInterval
+	}
+
+	if opts.Timezone == nil {
+		opts.Timezone = time.UTC
+	}
+
+	var errs []error
+
+	if len(sinks) == 0 {
+		errs = append(errs, errors.New("at least one alert sink is required"))
+	}
+
+	if opts.BaselineDays < 1 {
+		errs = append(errs, fmt.Errorf("invalid baseline days: must be at least 1, but got %d", opts.BaselineDays))
+	}
+
+	if opts.Threshold < 0 {
+		errs = append(errs, fmt.Errorf("invalid threshold: must be positive, but got %g", opts.Threshold))
+	}
+
+	if opts.MinBaseline < 2 {
+		errs = append(errs, fmt.Errorf("invalid min baseline: must be at least 2, but got %d", opts.MinBaseline))
+	}
+
+	if opts.Goal != exoclick.G1 && opts.Goal != exoclick.G5 {
+		errs = append(errs, fmt.Errorf("invalid goal field: \"%s\"", opts.Goal))
+	}
+
+	if opts.Interval < 0 {
+		errs = append(errs, fmt.Errorf("invalid interval: must be positive, but got %s", opts.Interval))
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	m := &Monitor{
+		fetcher: fetcher,
+		sinks:   sinks,
+		opts:    opts,
+		goal:    analysis.Field(opts.Goal),
+		now:     time.Now,
+		sent:    make(map[string]time.Time),
+	}
+
+	if opts.Dedupe != nil {
+		sent, err := opts.Dedupe.Load()
+		if err != nil {
+			return nil, fmt.Errorf("loading sent alerts: %w", err)
+		}
+
+		m.sent = sent
+	}
+
+	return m, nil
+}
+
+func (m *Monitor) Run(ctx context.Context) error {
+	ticker := time.NewTicker(m.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := m.Check(ctx); err != nil && m.opts.OnError != nil {
+			m.opts.OnError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (m *Monitor) Check(ctx context.Context) ([]Alert, error) {
+	now := m.now().In(m.opts.Timezone)
+	previous := now.Add(-time.Hour)
+	hour := exoclick.HourStart(previous, previous.Hour())
+
+	opts, err := exoclick.NewReport().
+		GroupBy(exoclick.CampaignID, exoclick.Date, exoclick.Hour).
+		Metrics(exoclick.MetricFields...).
+		Timezone(m.opts.Timezone).
+		Between(hour.AddDate(0, 0, -m.opts.BaselineDays), hour).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, _, err := m.fetcher.GetStatisticsCSV(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("fetching hourly statistics: %w", err)
+	}
+
+	alerts := m.Detect(now, hour, rows)
+
+	var errs []error
+
+	recorded := false
+
+	sent := alerts[:0]
+	for _, alert := range alerts {
+		key := alertKey(alert)
+
+		m.mu.Lock()
+		_, duplicate := m.sent[key]
+		m.mu.Unlock()
+
+		if duplicate {
+			continue
+		}
+
+		var sinkErrs []error
+		for _, sink := range m.sinks {
+			if err := sink.Send(ctx, alert); err != nil {
+				sinkErrs = append(sinkErrs, fmt.Errorf("sending %s alert for campaign %d: %w", alert.Kind, alert.CampaignID, err))
+			}
+		}
+
+		if len(sinkErrs) < len(m.sinks) {
+			m.mu.Lock()
+			m.sent[key] = alert.Hour
+			m.mu.Unlock()
+
+			recorded = true
+		}
+
+		errs = append(errs, sinkErrs...)
+		sent = append(sent, alert)
+	}
+
+	if err := m.prune(hour.Add(-2*exoclick.Day), recorded); err != nil {
+		errs = append(errs, fmt.Errorf("saving sent alerts: %w", err))
+	}
+
+	return sent, errors.Join(errs...)
+}
+
+func (m *Monitor) Detect(now, hour time.Time, rows []*exoclick.Statistic) []Alert {
+	series := make(map[int]map[time.Time]*exoclick.Statistic)
+
+	for _, row := range rows {
+		if row.CampaignID == nil {
+			continue
+		}
+
+		if len(m.opts.Campaigns) > 0 && !slices.Contains(m.opts.Campaigns, *row.CampaignID) {
+			continue
+		}
+
+		ts, ok := row.Timestamp()
+		if !ok {
+			continue
+		}
+
+		if series[*row.CampaignID] == nil {
+			series[*row.CampaignID] = make(map[time.Time]*exoclick.Statistic)
+		}
+
+		series[*row.CampaignID][ts] = row
+	}
+
+	campaignIDs := make([]int, 0, len(series))
+	for campaignID := range series {
+		campaignIDs = append(campaignIDs, campaignID)
+	}
+
+	sort.Ints(campaignIDs)
+
+	var alerts []Alert
+
+	for _, campaignID := range campaignIDs {
+		alerts = append(alerts, m.detectCampaign(now, hour, campaignID, series[campaignID])...)
+	}
+
+	return alerts
+}
+
+func (m *Monitor) detectCampaign(now, hour time.Time, campaignID int, series map[time.Time]*exoclick.Statistic) []Alert {
+	current := series[hour]
+	if current == nil {
+		return nil
+	}
+
+	baseline := m.baseline(hour, series)
+	if len(baseline) < m.opts.MinBaseline {
+		return nil
+	}
+
+	var alerts []Alert
+
+	check := func(kind Kind, metric analysis.Metric, rows []*exoclick.Statistic, spike bool, format string) {
+		mean, sd := meanStdDev(rows, metric)
+		value := metric(current)
+		z := (value - mean) / math.Max(sd, math.Max(0.1*math.Abs(mean), 1e-9))
+
+		if (spike && z > m.opts.Threshold) || (!spike && z < -m.opts.Threshold) {
+			alerts = append(alerts, Alert{
+				Time:       now,
+				Kind:       kind,
+				CampaignID: campaignID,
+				Hour:       hour,
+				Value:      value,
+				Baseline:   mean,
+				StdDev:     sd,
+				ZScore:     z,
+				Message:    fmt.Sprintf(format, campaignID, value, mean),
+			})
+		}
+	}
+
+	check(SpendSpike, analysis.Field(exoclick.Cost), baseline, true, "campaign %d spent %.2f in the last hour against a baseline of %.2f")
+	check(ImpressionDrop, analysis.Field(exoclick.Impressions), baseline, false, "campaign %d served %.0f impressions in the last hour against a baseline of %.0f")
+
+	if current.Impressions >= m.opts.MinImpressions {
+		var eligible []*exoclick.Statistic
+		for _, row := range baseline {
+			if row.Impressions >= m.opts.MinImpressions {
+				eligible = append(eligible, row)
+			}
+		}
+
+		if len(eligible) >= m.opts.MinBaseline {
+			check(CTRCollapse, analysis.CTR, eligible, false, "campaign %d CTR dropped to %.4f against a baseline of %.4f")
+		}
+	}
+
+	if expected, _ := meanStdDev(baseline, m.goal); current.Clicks > 0 && m.goal(current) == 0 && expected >= m.opts.MinExpectedConversion {
+		alerts = append(alerts, Alert{
+			Time:       now,
+			Kind:       ZeroConversions,
+			CampaignID: campaignID,
+			Hour:       hour,
+			Baseline:   expected,
+			Message:    fmt.Sprintf("campaign %d received %d clicks and no conversions in the last hour against a baseline of %.1f conversions", campaignID, current.Clicks, expected),
+		})
+	}
+
+	return alerts
+}
+
+func (m *Monitor) baseline(hour time.Time, series map[time.Time]*exoclick.Statistic) []*exoclick.Statistic {
+	var hours []time.Time
+
+	if m.opts.Seasonal {
+		for day := 1; day <= m.opts.BaselineDays; day++ {
+			hours = append(hours, hour.AddDate(0, 0, -day))
+		}
+	} else {
+		for h := hour.Add(-time.Hour); !h.Before(hour.AddDate(0, 0, -m.opts.BaselineDays)); h = h.Add(-time.Hour) {
+			hours = append(hours, h)
+		}
+	}
+
+	first := hour
+	for ts := range series {
+		if ts.Before(first) {
+			first = ts
+		}
+	}
+
+	var rows []*exoclick.Statistic
+
+	for _, h := range hours {
+		if h.Before(first) {
+			continue
+		}
+
+		row := series[h]
+		if row == nil {
+			row = &exoclick.Statistic{}
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows
+}
+
+func (m *Monitor) prune(before time.Time, changed bool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for key, hour := range m.sent {
+		if hour.Before(before) {
+			delete(m.sent, key)
+			changed = true
+		}
+	}
+
+	if m.opts.Dedupe == nil || !changed {
+		return nil
+	}
+
+	return m.opts.Dedupe.Save(m.sent)
+}
+
+func meanStdDev(rows []*exoclick.Statistic, metric analysis.Metric) (float64, float64) {
+	if len(rows) == 0 {
+		return 0, 0
+	}
+
+	var sum float64
+	for _, row := range rows {
+		sum += metric(row)
+	}
+
+	mean := sum / float64(len(rows))
+
+	var variance float64
+	for _, row := range rows {
+		d := metric(row) - mean
+		variance += d * d
+	}
+
+	if len(rows) > 1 {
+		variance /= float64(len(rows) - 1)
+	}
+
+	return mean, math.Sqrt(variance)
+}
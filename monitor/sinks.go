@@ -0,0 +1,84 @@
+package monitor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+)
+
+type AlertSink interface {
+	Send(ctx context.Context, alert Alert) error
+}
+
+type LogSink struct {
+	Logger *slog.Logger
+}
+
+func NewLogSink(logger *slog.Logger) *LogSink {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &LogSink{Logger: logger}
+}
+
+func (s *LogSink) Send(ctx context.Context, alert Alert) error {
+	s.Logger.LogAttrs(ctx, slog.LevelWarn, alert.Message,
+		slog.String("kind", string(alert.Kind)),
+		slog.Int("campaign_id", alert.CampaignID),
+		slog.Time("hour", alert.Hour),
+		slog.Float64("value", alert.Value),
+		slog.Float64("baseline", alert.Baseline),
+		slog.Float64("z_score", alert.ZScore),
+	)
+
+	return nil
+}
+
+type WebhookSink struct {
+	URL        string
+	Header     http.Header
+	HTTPClient *http.Client
+}
+
+func NewWebhookSink(url string) *WebhookSink {
+	return &WebhookSink{URL: url, HTTPClient: http.DefaultClient}
+}
+
+func (s *WebhookSink) Send(ctx context.Context, alert Alert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	for key, values := range s.Header {
+		req.Header[key] = values
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	client := s.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook %s responded with %s", s.URL, resp.Status)
+	}
+
+	return nil
+}
@@ -0,0 +1,18 @@
+package monitor
+
+import (
+	"time"
+
+	"github.com/adam-szerdahelyi/go-exoclick/filestore"
+)
+
+type DedupeStore interface {
+	Load() (map[string]time.Time, error)
+	Save(sent map[string]time.Time) error
+}
+
+type FileDedupeStore = filestore.JSON[time.Time]
+
+func NewFileDedupeStore(path string) *FileDedupeStore {
+	return filestore.NewJSON[time.Time](path)
+}
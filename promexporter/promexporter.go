@@ -0,0 +1,308 @@
+package promexporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/adam-szerdahelyi/go-exoclick/analysis"
+	"github.com/adam-szerdahelyi/go-exoclick/exoclick"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	namespace         = "exoclick"
+	DefaultInterval   = 5 * time.Minute
+	DefaultStatus     = 1
+	campaignListLimit = 500
+)
+
+var (
+	DefaultLabels = []exoclick.StatisticsField{exoclick.CampaignID}
+
+	allowedLabels = []exoclick.StatisticsField{
+		exoclick.CampaignID,
+		exoclick.VariationID,
+		exoclick.SiteID,
+		exoclick.ZoneID,
+		exoclick.CategoryID,
+	}
+)
+
+type Fetcher interface {
+	GetStatisticsCSV(ctx context.Context, opts *exoclick.StatisticsOptions) ([]*exoclick.Statistic, *http.Response, error)
+}
+
+type CampaignLister interface {
+	List(ctx context.Context, opts *exoclick.CampaignListOptions) ([]*exoclick.CampaignData, *http.Response, error)
+}
+
+type Options struct {
+	Labels    []exoclick.StatisticsField
+	Campaigns []int
+	Status    int
+	Interval  time.Duration
+	Timezone  *time.Location
+	OnError   func(error)
+}
+
+type metric struct {
+	desc  *prometheus.Desc
+	value func(s *exoclick.Statistic) float64
+}
+
+type Exporter struct {
+	fetcher Fetcher
+	lister  CampaignLister
+	opts    Options
+	labels  []string
+	metrics []metric
+
+	scrapes    prometheus.Counter
+	errors     prometheus.Counter
+	lastScrape prometheus.Gauge
+
+	mu   sync.RWMutex
+	rows []*exoclick.Statistic
+}
+
+func New(fetcher Fetcher, lister CampaignLister, opts Options) (*Exporter, error) {
+	if len(opts.Labels) == 0 {
+		opts.Labels = DefaultLabels
+	}
+
+	if opts.Status == 0 {
+		opts.Status = DefaultStatus
+	}
+
+	if opts.Interval == 0 {
+		opts.Interval = DefaultInterval
+	}
+
+	if opts.Timezone == nil {
+		opts.Timezone = time.UTC
+	}
+
+	var errs []error
+
+	if len(opts.Campaigns) == 0 && lister == nil {
+		errs = append(errs, errors.New("a campaign lister is required when no campaigns are set"))
+	}
+
+	if opts.Interval < 0 {
+		errs = append(errs, fmt.Errorf("invalid interval: must be positive, but got %s", opts.Interval))
+	}
+
+	if fields := groupBy(opts.Labels); len(fields) > 4 {
+		errs = append(errs, fmt.Errorf("invalid labels: maximum of 4 fields including campaign_id allowed, but got %d", len(fields)))
+	}
+
+	labels := make([]string, len(opts.Labels))
+	for i, field := range opts.Labels {
+		if !slices.Contains(allowedLabels, field) {
+			errs = append(errs, fmt.Errorf("invalid label field: \"%s\"", field))
+		}
+
+		labels[i] = string(field)
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	e := &Exporter{
+		fetcher: fetcher,
+		lister:  lister,
+		opts:    opts,
+		labels:  labels,
+		scrapes: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "statistics_scrapes_total",
+			Help:      "Number of statistics pulls from the ExoClick API.",
+		}),
+		errors: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "statistics_scrape_errors_total",
+			Help:      "Number of failed statistics pulls from the ExoClick API.",
+		}),
+		lastScrape: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "statistics_last_scrape_timestamp_seconds",
+			Help:      "Unix time of the last successful statistics pull.",
+		}),
+	}
+
+	gauge := func(name, help string, value func(s *exoclick.Statistic) float64) metric {
+		return metric{
+			desc:  prometheus.NewDesc(prometheus.BuildFQName(namespace, "", name), help, labels, nil),
+			value: value,
+		}
+	}
+
+	e.metrics = []metric{
+		gauge("cost_today", "Spend for the current day.", func(s *exoclick.Statistic) float64 { return float64(s.Cost) }),
+		gauge("impressions_today", "Impressions for the current day.", func(s *exoclick.Statistic) float64 { return float64(s.Impressions) }),
+		gauge("clicks_today", "Clicks for the current day.", func(s *exoclick.Statistic) float64 { return float64(s.Clicks) }),
+		gauge("video_views_today", "Video views for the current day.", func(s *exoclick.Statistic) float64 { return float64(s.VideoViews) }),
+		gauge("goal1_conversions_today", "Goal 1 conversions for the current day.", func(s *exoclick.Statistic) float64 { return float64(s.G1) }),
+		gauge("goal5_conversions_today", "Goal 5 conversions for the current day.", func(s *exoclick.Statistic) float64 { return float64(s.G5) }),
+	}
+
+	return e, nil
+}
+
+func (e *Exporter) Describe(ch chan<- *prometheus.Desc) {
+	for _, m := range e.metrics {
+		ch <- m.desc
+	}
+
+	e.scrapes.Describe(ch)
+	e.errors.Describe(ch)
+	e.lastScrape.Describe(ch)
+}
+
+func (e *Exporter) Collect(ch chan<- prometheus.Metric) {
+	e.mu.RLock()
+	rows := e.rows
+	e.mu.RUnlock()
+
+	for _, row := range rows {
+		values := make([]string, len(e.opts.Labels))
+		for i, field := range e.opts.Labels {
+			values[i], _ = row.Dimension(field)
+		}
+
+		for _, m := range e.metrics {
+			ch <- prometheus.MustNewConstMetric(m.desc, prometheus.GaugeValue, m.value(row), values...)
+		}
+	}
+
+	e.scrapes.Collect(ch)
+	e.errors.Collect(ch)
+	e.lastScrape.Collect(ch)
+}
+
+func (e *Exporter) Handler() http.Handler {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(e)
+
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+func (e *Exporter) Refresh(ctx context.Context) error {
+	e.scrapes.Inc()
+
+	now := time.Now().In(e.opts.Timezone)
+	today := exoclick.CustomDate{Time: time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, e.opts.Timezone)}
+
+	fields := groupBy(e.opts.Labels)
+
+	opts := exoclick.StatisticsOptions{
+		Timezone:        &exoclick.TimeZone{Location: e.opts.Timezone},
+		Filter:          exoclick.StatisticsFilters{DateFrom: today, DateTo: today},
+		GroupBy:         fields,
+		OutputCsvFields: append(slices.Clone(fields), exoclick.MetricFields...),
+	}
+
+	campaigns := e.opts.Campaigns
+	if len(campaigns) == 0 {
+		var err error
+		if campaigns, err = e.campaigns(ctx); err != nil {
+			e.errors.Inc()
+			return fmt.Errorf("listing campaigns: %w", err)
+		}
+	}
+
+	result, _, err := e.fetcher.GetStatisticsCSV(ctx, &opts)
+	if err != nil {
+		e.errors.Inc()
+		return err
+	}
+
+	wanted := make(map[int]bool, len(campaigns))
+	for _, campaignID := range campaigns {
+		wanted[campaignID] = true
+	}
+
+	var rows []*exoclick.Statistic
+
+	for _, row := range result {
+		if row.CampaignID != nil && wanted[*row.CampaignID] {
+			rows = append(rows, row)
+		}
+	}
+
+	rows, err = analysis.GroupBy(rows, e.opts.Labels...)
+	if err != nil {
+		e.errors.Inc()
+		return err
+	}
+
+	e.mu.Lock()
+	e.rows = rows
+	e.mu.Unlock()
+
+	e.lastScrape.SetToCurrentTime()
+
+	return nil
+}
+
+func (e *Exporter) Run(ctx context.Context) error {
+	ticker := time.NewTicker(e.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := e.Refresh(ctx); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			if e.opts.OnError != nil {
+				e.opts.OnError(err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (e *Exporter) campaigns(ctx context.Context) ([]int, error) {
+	var ids []int
+
+	for offset := 0; ; offset += campaignListLimit {
+		campaigns, _, err := e.lister.List(ctx, &exoclick.CampaignListOptions{
+			Status:      e.opts.Status,
+			ListOptions: exoclick.ListOptions{Limit: campaignListLimit, Offset: offset},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, c := range campaigns {
+			if c.ID != nil {
+				ids = append(ids, *c.ID)
+			}
+		}
+
+		if len(campaigns) < campaignListLimit {
+			return ids, nil
+		}
+	}
+}
+
+func groupBy(labels []exoclick.StatisticsField) []exoclick.StatisticsField {
+	if slices.Contains(labels, exoclick.CampaignID) {
+		return labels
+	}
+
+	return append([]exoclick.StatisticsField{exoclick.CampaignID}, labels...)
+}
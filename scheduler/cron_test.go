@@ -0,0 +1,82 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func TestParseCronRejectsNeverFiring(t *testing.T) {
+	for _, spec := range []string{"0 0 30 2 *", "0 0 31 4 *"} {
+		if _, err := ParseCron(spec, time.UTC); err == nil {
+			t.Errorf("ParseCron(%q) returned no error", spec)
+		}
+	}
+
+	if _, err := ParseCron("0 0 29 2 *", time.UTC); err != nil {
+		t.Errorf("ParseCron(%q) returned error: %v", "0 0 29 2 *", err)
+	}
+}
+
+func TestCronRunsOnceOnFallBack(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schedule, err := ParseCron("30 1 * * *", loc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first := schedule.Next(time.Date(2024, time.November, 3, 0, 0, 0, 0, loc))
+	if want := time.Date(2024, time.November, 3, 5, 30, 0, 0, time.UTC); !first.Equal(want) {
+		t.Fatalf("first run = %s, want %s", first, want)
+	}
+
+	second := schedule.Next(first)
+	if want := time.Date(2024, time.November, 4, 6, 30, 0, 0, time.UTC); !second.Equal(want) {
+		t.Errorf("second run = %s, want %s", second, want)
+	}
+
+	hourly, err := ParseCron("30 * * * *", loc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if next := hourly.Next(first); !next.Equal(first.Add(time.Hour)) {
+		t.Errorf("hourly run after %s = %s, want %s", first, next, first.Add(time.Hour))
+	}
+}
+
+func TestCronRunsAfterSpringForwardGap(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schedule, err := ParseCron("30 2 * * *", loc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first := schedule.Next(time.Date(2024, time.March, 10, 0, 0, 0, 0, loc))
+	if want := time.Date(2024, time.March, 10, 7, 0, 0, 0, time.UTC); !first.Equal(want) {
+		t.Fatalf("first run = %s, want %s", first, want.In(loc))
+	}
+
+	second := schedule.Next(first)
+	if want := time.Date(2024, time.March, 11, 6, 30, 0, 0, time.UTC); !second.Equal(want) {
+		t.Errorf("second run = %s, want %s", second, want.In(loc))
+	}
+
+	hourly, err := ParseCron("30 * * * *", loc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2024, time.March, 10, 1, 30, 0, 0, loc)
+	if next, want := hourly.Next(start), start.Add(time.Hour); !next.Equal(want) {
+		t.Errorf("hourly run after %s = %s, want %s", start, next, want)
+	}
+}
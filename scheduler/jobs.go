@@ -0,0 +1,58 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/adam-szerdahelyi/go-exoclick/automation"
+	"github.com/adam-szerdahelyi/go-exoclick/exoclick"
+	"github.com/adam-szerdahelyi/go-exoclick/export"
+	"github.com/adam-szerdahelyi/go-exoclick/statsync"
+)
+
+func SyncJob(syncer *statsync.Syncer, reports ...statsync.Report) JobFunc {
+	return func(ctx context.Context, _ *exoclick.Client) error {
+		var errs []error
+
+		for _, report := range reports {
+			if _, err := syncer.Sync(ctx, report); err != nil {
+				errs = append(errs, fmt.Errorf("syncing report %q: %w", report.Name, err))
+			}
+		}
+
+		return errors.Join(errs...)
+	}
+}
+
+func RuleJob(engine *automation.Engine, campaignIDs ...int) JobFunc {
+	return func(ctx context.Context, _ *exoclick.Client) error {
+		var errs []error
+
+		for _, campaignID := range campaignIDs {
+			if _, err := engine.Evaluate(ctx, campaignID); err != nil {
+				errs = append(errs, err)
+			}
+		}
+
+		return errors.Join(errs...)
+	}
+}
+
+func ExportJob(report *exoclick.ReportBuilder, open func() (export.Writer, error)) JobFunc {
+	return func(ctx context.Context, client *exoclick.Client) error {
+		opts, err := report.Build()
+		if err != nil {
+			return err
+		}
+
+		w, err := open()
+		if err != nil {
+			return err
+		}
+
+		_, err = client.Statistics.StreamStatisticsCSV(ctx, opts, w.Write)
+
+		return errors.Join(err, w.Close())
+	}
+}
@@ -0,0 +1,404 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/adam-szerdahelyi/go-exoclick/exoclick"
+	"github.com/adam-szerdahelyi/go-exoclick/filestore"
+)
+
+const (
+	DefaultTimeout          = 30 * time.Minute
+	DefaultFailureThreshold = 3
+)
+
+type JobFunc func(ctx context.Context, client *exoclick.Client) error
+
+type Job struct {
+	Name     string
+	Schedule string
+	Timeout  time.Duration
+	Run      JobFunc
+}
+
+type State struct {
+	LastStart   time.Time `json:"last_start,omitempty"`
+	LastEnd     time.Time `json:"last_end,omitempty"`
+	LastSuccess time.Time `json:"last_success,omitempty"`
+	LastError   string    `json:"last_error,omitempty"`
+	Next        time.Time `json:"next,omitempty"`
+	Runs        int       `json:"runs"`
+	Failures    int       `json:"failures"`
+	Consecutive int       `json:"consecutive_failures"`
+	Skipped     int       `json:"skipped"`
+	Running     bool      `json:"running"`
+}
+
+type StateStore interface {
+	Load() (map[string]State, error)
+	Save(states map[string]State) error
+}
+
+type FileStore = filestore.JSON[State]
+
+func NewFileStore(path string) *FileStore {
+	return filestore.NewJSON[State](path)
+}
+
+type Options struct {
+	Store            StateStore
+	Location         *time.Location
+	FailureThreshold int
+	StaleAfter       time.Duration
+	OnError          func(job string, err error)
+}
+
+type job struct {
+	Job
+	schedule Schedule
+}
+
+type Runner struct {
+	client *exoclick.Client
+	opts   Options
+	jobs   []*job
+	now    func() time.Time
+
+	mu     sync.Mutex
+	states map[string]State
+	saveMu sync.Mutex
+	wg     sync.WaitGroup
+}
+
+func New(client *exoclick.Client, opts Options, jobs ...Job) (*Runner, error) {
+	if opts.Location == nil {
+		opts.Location = time.UTC
+	}
+
+	if opts.FailureThreshold == 0 {
+		opts.FailureThreshold = DefaultFailureThreshold
+	}
+
+	r := &Runner{
+		client: client,
+		opts:   opts,
+		now:    time.Now,
+		states: make(map[string]State),
+	}
+
+	var errs []error
+
+	if len(jobs) == 0 {
+		errs = append(errs, errors.New("at least one job is required"))
+	}
+
+	if opts.FailureThreshold < 0 {
+		errs = append(errs, fmt.Errorf("invalid failure threshold: must be positive, but got %d", opts.FailureThreshold))
+	}
+
+	if opts.StaleAfter < 0 {
+		errs = append(errs, fmt.Errorf("invalid staleness threshold: must be positive, but got %s", opts.StaleAfter))
+	}
+
+	names := make(map[string]bool)
+
+	for _, j := range jobs {
+		if j.Name == "" {
+			errs = append(errs, errors.New("job name cannot be empty"))
+			continue
+		}
+
+		if names[j.Name] {
+			errs = append(errs, fmt.Errorf("duplicate job name %q", j.Name))
+		}
+
+		names[j.Name] = true
+
+		if j.Run == nil {
+			errs = append(errs, fmt.Errorf("job %q: run function cannot be nil", j.Name))
+		}
+
+		if j.Timeout == 0 {
+			j.Timeout = DefaultTimeout
+		}
+
+		if j.Timeout < 0 {
+			errs = append(errs, fmt.Errorf("job %q: invalid timeout: must be positive, but got %s", j.Name, j.Timeout))
+		}
+
+		schedule, err := ParseCron(j.Schedule, opts.Location)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("job %q: %w", j.Name, err))
+		}
+
+		r.jobs = append(r.jobs, &job{Job: j, schedule: schedule})
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	if opts.Store != nil {
+		states, err := opts.Store.Load()
+		if err != nil {
+			return nil, fmt.Errorf("loading job state: %w", err)
+		}
+
+		for name, state := range states {
+			if names[name] {
+				state.Running = false
+				r.states[name] = state
+			}
+		}
+	}
+
+	return r, nil
+}
+
+func (r *Runner) Run(ctx context.Context) error {
+	defer r.wg.Wait()
+
+	now := r.now()
+
+	r.mu.Lock()
+	for _, j := range r.jobs {
+		state := r.states[j.Name]
+		state.Next = j.schedule.Next(now)
+		r.states[j.Name] = state
+	}
+	r.mu.Unlock()
+
+	for {
+		next, due := r.due(r.now())
+
+		for _, j := range due {
+			r.start(ctx, j)
+		}
+
+		if len(due) > 0 {
+			continue
+		}
+
+		if next.IsZero() {
+			<-ctx.Done()
+			return ctx.Err()
+		}
+
+		timer := time.NewTimer(time.Until(next))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (r *Runner) RunJob(ctx context.Context, name string) error {
+	for _, j := range r.jobs {
+		if j.Name == name {
+			if !r.begin(j) {
+				return fmt.Errorf("job %q is already running", name)
+			}
+
+			return r.execute(ctx, j)
+		}
+	}
+
+	return fmt.Errorf("unknown job %q", name)
+}
+
+func (r *Runner) States() map[string]State {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	states := make(map[string]State, len(r.states))
+	for name, state := range r.states {
+		states[name] = state
+	}
+
+	return states
+}
+
+func (r *Runner) HealthHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		states := r.States()
+		now := r.now()
+
+		status := http.StatusOK
+		health := struct {
+			Status string           `json:"status"`
+			Jobs   map[string]State `json:"jobs"`
+			Failed []string         `json:"failed,omitempty"`
+		}{Status: "ok", Jobs: states}
+
+		for name, state := range states {
+			if r.failing(state, now) {
+				health.Failed = append(health.Failed, name)
+			}
+		}
+
+		if len(health.Failed) > 0 {
+			sort.Strings(health.Failed)
+			health.Status = "failing"
+			status = http.StatusServiceUnavailable
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(health)
+	})
+}
+
+func (r *Runner) failing(state State, now time.Time) bool {
+	if state.Consecutive >= r.opts.FailureThreshold {
+		return true
+	}
+
+	if r.opts.StaleAfter == 0 || state.LastSuccess.IsZero() {
+		return false
+	}
+
+	return now.Sub(state.LastSuccess) > r.opts.StaleAfter
+}
+
+func (r *Runner) due(now time.Time) (time.Time, []*job) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var (
+		next time.Time
+		due  []*job
+	)
+
+	for _, j := range r.jobs {
+		state := r.states[j.Name]
+		if state.Next.IsZero() {
+			continue
+		}
+
+		if !state.Next.After(now) {
+			due = append(due, j)
+			state.Next = j.schedule.Next(now)
+			r.states[j.Name] = state
+		}
+
+		if next.IsZero() || state.Next.Before(next) {
+			next = state.Next
+		}
+	}
+
+	return next, due
+}
+
+func (r *Runner) start(ctx context.Context, j *job) {
+	if !r.begin(j) {
+		r.mu.Lock()
+		state := r.states[j.Name]
+		state.Skipped++
+		r.states[j.Name] = state
+		r.mu.Unlock()
+
+		r.report(j.Name, errors.New("skipped: previous run still in progress"))
+		r.save(j.Name)
+
+		return
+	}
+
+	r.wg.Add(1)
+
+	go func() {
+		defer r.wg.Done()
+		r.execute(ctx, j)
+	}()
+}
+
+func (r *Runner) begin(j *job) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	state := r.states[j.Name]
+	if state.Running {
+		return false
+	}
+
+	state.Running = true
+	state.LastStart = r.now()
+	r.states[j.Name] = state
+
+	return true
+}
+
+func (r *Runner) execute(parent context.Context, j *job) error {
+	ctx, cancel := context.WithTimeout(parent, j.Timeout)
+	defer cancel()
+
+	err := func() (err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				err = fmt.Errorf("panic: %v", p)
+			}
+		}()
+
+		return j.Run(ctx, r.client)
+	}()
+
+	if parent.Err() == nil && ctx.Err() == context.DeadlineExceeded {
+		err = fmt.Errorf("timed out after %s: %w", j.Timeout, context.DeadlineExceeded)
+	}
+
+	r.mu.Lock()
+	state := r.states[j.Name]
+	state.Running = false
+	state.LastEnd = r.now()
+	state.Runs++
+
+	if err != nil {
+		state.Failures++
+		state.Consecutive++
+		state.LastError = err.Error()
+	} else {
+		state.Consecutive = 0
+		state.LastError = ""
+		state.LastSuccess = state.LastEnd
+	}
+
+	r.states[j.Name] = state
+	r.mu.Unlock()
+
+	if err != nil {
+		r.report(j.Name, err)
+	}
+
+	r.save(j.Name)
+
+	return err
+}
+
+func (r *Runner) save(name string) {
+	if r.opts.Store == nil {
+		return
+	}
+
+	r.saveMu.Lock()
+	err := r.opts.Store.Save(r.States())
+	r.saveMu.Unlock()
+
+	if err != nil {
+		r.report(name, fmt.Errorf("saving job state: %w", err))
+	}
+}
+
+func (r *Runner) report(name string, err error) {
+	if r.opts.OnError != nil {
+		r.opts.OnError(name, err)
+	}
+}
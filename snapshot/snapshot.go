@@ -0,0 +1,357 @@
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/adam-szerdahelyi/go-exoclick/exoclick"
+)
+
+const (
+	BundleVersion = 1
+
+	fileListLimit = 500
+)
+
+type Bundle struct {
+	Version    int               `json:"version"`
+	ExportedAt time.Time         `json:"exported_at"`
+	Campaign   exoclick.Campaign `json:"campaign"`
+	Files      []BundleFile      `json:"files,omitempty"`
+}
+
+type BundleFile struct {
+	File    exoclick.File `json:"file"`
+	Content []byte        `json:"content,omitempty"`
+}
+
+func (b Bundle) String() string {
+	return exoclick.Stringify(b)
+}
+
+func (b *Bundle) WriteTo(w io.Writer) (int64, error) {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := w.Write(data)
+
+	return int64(n), err
+}
+
+func ReadBundle(r io.Reader) (*Bundle, error) {
+	bundle := new(Bundle)
+	if err := json.NewDecoder(r).Decode(bundle); err != nil {
+		return nil, err
+	}
+
+	if bundle.Version != BundleVersion {
+		return nil, fmt.Errorf("unsupported bundle version %d", bundle.Version)
+	}
+
+	return bundle, nil
+}
+
+type ExportOptions struct {
+	IncludeContent bool
+	HTTPClient     *http.Client
+}
+
+func Export(ctx context.Context, client *exoclick.Client, campaignID int, opts ExportOptions) (*Bundle, error) {
+	campaign, _, err := client.Campaigns.Get(ctx, campaignID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := &Bundle{
+		Version:    BundleVersion,
+		ExportedAt: time.Now().UTC(),
+		Campaign:   *campaign,
+	}
+
+	if campaign.Variations == nil {
+		return bundle, nil
+	}
+
+	seen := make(map[int]bool)
+
+	for _, variation := range *campaign.Variations {
+		if variation.FileID == 0 || seen[variation.FileID] {
+			continue
+		}
+
+		seen[variation.FileID] = true
+
+		file, _, err := client.File.Get(ctx, variation.FileID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch file %d: %w", variation.FileID, err)
+		}
+
+		bf := BundleFile{File: *file}
+
+		if opts.IncludeContent {
+			if bf.Content, err = download(ctx, opts.HTTPClient, file.URL); err != nil {
+				return nil, fmt.Errorf("failed to download file %d: %w", file.ID, err)
+			}
+		}
+
+		bundle.Files = append(bundle.Files, bf)
+	}
+
+	return bundle, nil
+}
+
+type ImportOptions struct {
+	Name       string
+	Paused     bool
+	HTTPClient *http.Client
+}
+
+type ImportResult struct {
+	CampaignID    int
+	FileIDs       map[int]int
+	UploadedFiles []int
+	VariationIDs  map[int]int
+	Warnings      []string
+}
+
+func (r ImportResult) String() string {
+	return exoclick.Stringify(r)
+}
+
+func Import(ctx context.Context, client *exoclick.Client, bundle *Bundle, opts ImportOptions) (*ImportResult, error) {
+	source := bundle.Campaign
+	if source.Campaign == nil {
+		return nil, errors.New("bundle does not contain campaign settings")
+	}
+
+	result := &ImportResult{
+		FileIDs:      make(map[int]int),
+		VariationIDs: make(map[int]int),
+	}
+
+	if source.CampaignCategories != nil && len(source.CampaignCategories.Blocked) > 0 {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("skipped %d blocked categories; blocked categories cannot be restored through the API", len(source.CampaignCategories.Blocked)))
+	}
+
+	if err := importFiles(ctx, client, bundle.Files, opts, result); err != nil {
+		return result, err
+	}
+
+	paused := exoclick.CampaignPaused
+
+	settings := *source.Campaign
+	settings.ID = nil
+	settings.Status = &paused
+	settings.DateCreated = nil
+	if opts.Name != "" {
+		settings.Name = &opts.Name
+	}
+
+	created, _, err := client.Campaigns.Create(ctx, &settings)
+	if err != nil {
+		return result, fmt.Errorf("failed to create campaign: %w", err)
+	}
+
+	if created.ID == nil {
+		return result, errors.New("created campaign has no id")
+	}
+
+	result.CampaignID = *created.ID
+
+	if err := configure(ctx, client, source, result); err != nil {
+		return result, fmt.Errorf("campaign %d was created but left paused: %w", result.CampaignID, err)
+	}
+
+	if !opts.Paused {
+		if _, err := client.Campaigns.ChangeCampaignStatus(ctx, exoclick.Play, result.CampaignID); err != nil {
+			return result, fmt.Errorf("failed to activate campaign %d: %w", result.CampaignID, err)
+		}
+	}
+
+	return result, nil
+}
+
+func configure(ctx context.Context, client *exoclick.Client, source exoclick.Campaign, result *ImportResult) error {
+	if source.CampaignCategories != nil && len(source.CampaignCategories.Targeted) > 0 {
+		var ids []int
+		for _, category := range source.CampaignCategories.Targeted {
+			if category.ID != nil {
+				ids = append(ids, *category.ID)
+			}
+		}
+
+		if _, err := client.Campaigns.TargetCategories(ctx, result.CampaignID, ids); err != nil {
+			return fmt.Errorf("failed to target categories: %w", err)
+		}
+	}
+
+	if source.CampaignZones != nil && len(*source.CampaignZones) > 0 {
+		if source.ZoneTargeting != nil && exoclick.TargetingType(source.ZoneTargeting.Type) == exoclick.Block {
+			var ids []int
+			for _, zone := range *source.CampaignZones {
+				if zone.ZoneID != nil {
+					ids = append(ids, *zone.ZoneID)
+				}
+			}
+
+			if _, err := client.Campaigns.BlockZones(ctx, result.CampaignID, ids); err != nil {
+				return fmt.Errorf("failed to block zones: %w", err)
+			}
+		} else {
+			zones := make([]exoclick.CampaignZones, len(*source.CampaignZones))
+			for i, zone := range *source.CampaignZones {
+				zone.CampaignID = &result.CampaignID
+				zones[i] = zone
+			}
+
+			if _, err := client.Campaigns.TargetZones(ctx, result.CampaignID, zones); err != nil {
+				return fmt.Errorf("failed to target zones: %w", err)
+			}
+		}
+	}
+
+	if source.Dayparting != nil {
+		if _, err := client.Campaigns.SetSchedule(ctx, result.CampaignID, source.Dayparting); err != nil {
+			return fmt.Errorf("failed to set dayparting: %w", err)
+		}
+	}
+
+	if source.Variations != nil {
+		for _, variation := range *source.Variations {
+			oldID := variation.ID
+
+			variation.ID = 0
+			if variation.FileID != 0 {
+				newID, ok := result.FileIDs[variation.FileID]
+				if !ok {
+					return fmt.Errorf("variation %d references file %d which is not in the bundle", oldID, variation.FileID)
+				}
+
+				variation.FileID = newID
+			}
+
+			createdVariation, _, err := client.Campaigns.CreateVariation(ctx, result.CampaignID, variation.Data())
+			if err != nil {
+				return fmt.Errorf("failed to create variation %d: %w", oldID, err)
+			}
+
+			result.VariationIDs[oldID] = createdVariation.ID
+
+			if variation.Active == 0 {
+				if _, err := client.Campaigns.ChangeVariationStatus(ctx, result.CampaignID, createdVariation.ID, exoclick.Pause); err != nil {
+					return fmt.Errorf("failed to pause variation %d: %w", createdVariation.ID, err)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func importFiles(ctx context.Context, client *exoclick.Client, files []BundleFile, opts ImportOptions, result *ImportResult) error {
+	existing := make(map[exoclick.FileType]map[string]int)
+
+	for _, bf := range files {
+		byHash, ok := existing[bf.File.Type]
+		if !ok {
+			var err error
+			if byHash, err = filesByHash(ctx, client, bf.File.Type); err != nil {
+				return fmt.Errorf("failed to list %s files: %w", bf.File.Type, err)
+			}
+
+			existing[bf.File.Type] = byHash
+		}
+
+		if id, ok := byHash[bf.File.FileHashOriginal]; ok && bf.File.FileHashOriginal != "" {
+			result.FileIDs[bf.File.ID] = id
+			continue
+		}
+
+		content := bf.Content
+		if content == nil {
+			var err error
+			if content, err = download(ctx, opts.HTTPClient, bf.File.URL); err != nil {
+				return fmt.Errorf("failed to download file %d: %w", bf.File.ID, err)
+			}
+		}
+
+		name := bf.File.FileName
+		if name == "" {
+			name = path.Base(bf.File.URL)
+		}
+
+		uploaded, _, err := client.File.Upload(ctx, bytes.NewReader(content), &exoclick.FileUploadOptions{
+			Type:     bf.File.Type,
+			FileName: name,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to upload file %d: %w", bf.File.ID, err)
+		}
+
+		byHash[bf.File.FileHashOriginal] = uploaded.ID
+		result.FileIDs[bf.File.ID] = uploaded.ID
+		result.UploadedFiles = append(result.UploadedFiles, uploaded.ID)
+	}
+
+	return nil
+}
+
+func filesByHash(ctx context.Context, client *exoclick.Client, fileType exoclick.FileType) (map[string]int, error) {
+	byHash := make(map[string]int)
+
+	for offset := 0; ; offset += fileListLimit {
+		files, _, err := client.File.List(ctx, &exoclick.FileListOptions{
+			Type:        fileType,
+			ListOptions: exoclick.ListOptions{Limit: fileListLimit, Offset: offset},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, file := range files {
+			if file.FileHashOriginal != "" {
+				byHash[file.FileHashOriginal] = file.ID
+			}
+		}
+
+		if len(files) < fileListLimit {
+			return byHash, nil
+		}
+	}
+}
+
+func download(ctx context.Context, httpClient *http.Client, url string) ([]byte, error) {
+	if url == "" {
+		return nil, errors.New("file has no url")
+	}
+
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	return io.ReadAll(resp.Body)
+}
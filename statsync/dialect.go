@@ -0,0 +1,68 @@
+package statsync
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/adam-szerdahelyi/go-exoclick/exoclick"
+)
+
+type Dialect interface {
+	Placeholder(n int) string
+	ColumnType(field exoclick.StatisticsField) string
+	DateValue(t time.Time) any
+}
+
+var (
+	SQLite   Dialect = sqliteDialect{}
+	Postgres Dialect = postgresDialect{}
+)
+
+type sqliteDialect struct{}
+
+func (sqliteDialect) Placeholder(int) string {
+	return "?"
+}
+
+func (sqliteDialect) ColumnType(field exoclick.StatisticsField) string {
+	switch field {
+	case exoclick.Date, exoclick.SiteName, exoclick.ZoneName:
+		return "TEXT"
+	case exoclick.Cost:
+		return "REAL"
+	default:
+		return "INTEGER"
+	}
+}
+
+func (sqliteDialect) DateValue(t time.Time) any {
+	return t.Format("2006-01-02")
+}
+
+type postgresDialect struct{}
+
+func (postgresDialect) Placeholder(n int) string {
+	return "$" + strconv.Itoa(n)
+}
+
+func (postgresDialect) ColumnType(field exoclick.StatisticsField) string {
+	switch field {
+	case exoclick.Date:
+		return "DATE"
+	case exoclick.SiteName, exoclick.ZoneName:
+		return "TEXT"
+	case exoclick.Cost:
+		return "DOUBLE PRECISION"
+	default:
+		return "BIGINT"
+	}
+}
+
+func (postgresDialect) DateValue(t time.Time) any {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func quote(identifier string) string {
+	return `"` + strings.ReplaceAll(identifier, `"`, `""`) + `"`
+}
@@ -0,0 +1,368 @@
+package statsync
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/adam-szerdahelyi/go-exoclick/exoclick"
+)
+
+const (
+	DefaultTrailingDays = 3
+	WatermarkTable      = "exoclick_sync_watermarks"
+
+	statisticsPageLimit = 1000
+)
+
+type Fetcher interface {
+	GetStatisticsCSV(ctx context.Context, opts *exoclick.StatisticsOptions) ([]*exoclick.Statistic, *http.Response, error)
+}
+
+type Report struct {
+	Name         string
+	Table        string
+	Options      exoclick.StatisticsOptions
+	Start        time.Time
+	TrailingDays int
+}
+
+func (r Report) validate() error {
+	var errs []error
+
+	if r.Name == "" {
+		errs = append(errs, errors.New("report name must be set"))
+	}
+
+	if r.Table == "" {
+		errs = append(errs, errors.New("report table must be set"))
+	}
+
+	if r.Start.IsZero() {
+		errs = append(errs, errors.New("report start must be set"))
+	}
+
+	if r.TrailingDays < 0 {
+		errs = append(errs, fmt.Errorf("invalid trailing days: must not be negative, but got %d", r.TrailingDays))
+	}
+
+	if !slices.Contains(r.Options.GroupBy, exoclick.Date) {
+		errs = append(errs, errors.New("report must be grouped by date"))
+	}
+
+	return errors.Join(errs...)
+}
+
+func (r Report) trailingDays() int {
+	if r.TrailingDays == 0 {
+		return DefaultTrailingDays
+	}
+
+	return r.TrailingDays
+}
+
+func (r Report) columns() []exoclick.StatisticsField {
+	columns := slices.Clone(r.Options.GroupBy)
+
+	for _, field := range r.Options.OutputCsvFields {
+		if !slices.Contains(columns, field) {
+			columns = append(columns, field)
+		}
+	}
+
+	return columns
+}
+
+type Result struct {
+	From time.Time
+	To   time.Time
+	Rows int
+}
+
+type Syncer struct {
+	db      *sql.DB
+	dialect Dialect
+	fetcher Fetcher
+}
+
+func New(db *sql.DB, dialect Dialect, fetcher Fetcher) *Syncer {
+	return &Syncer{db: db, dialect: dialect, fetcher: fetcher}
+}
+
+func (s *Syncer) Migrate(ctx context.Context, r Report) error {
+	if err := r.validate(); err != nil {
+		return err
+	}
+
+	var definitions []string
+	for _, field := range r.columns() {
+		definition := quote(string(field)) + " " + s.dialect.ColumnType(field)
+
+		if slices.Contains(r.Options.GroupBy, field) {
+			definition += " NOT NULL"
+
+			if field != exoclick.Date {
+				_, literal := keyDefault(field)
+				definition += " DEFAULT " + literal
+			}
+		}
+
+		definitions = append(definitions, definition)
+	}
+
+	var keys []string
+	for _, field := range r.Options.GroupBy {
+		keys = append(keys, quote(string(field)))
+	}
+
+	definitions = append(definitions, "PRIMARY KEY ("+strings.Join(keys, ", ")+")")
+
+	statements := []string{
+		fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (%s)", quote(r.Table), strings.Join(definitions, ", ")),
+		fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (report TEXT PRIMARY KEY, synced_to TEXT NOT NULL)", quote(WatermarkTable)),
+	}
+
+	for _, statement := range statements {
+		if _, err := s.db.ExecContext(ctx, statement); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Syncer) Watermark(ctx context.Context, report string) (time.Time, error) {
+	query := fmt.Sprintf("SELECT synced_to FROM %s WHERE report = %s", quote(WatermarkTable), s.dialect.Placeholder(1))
+
+	var syncedTo string
+
+	err := s.db.QueryRowContext(ctx, query, report).Scan(&syncedTo)
+	if errors.Is(err, sql.ErrNoRows) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Parse("2006-01-02", syncedTo)
+}
+
+func (s *Syncer) Sync(ctx context.Context, r Report) (*Result, error) {
+	if err := r.validate(); err != nil {
+		return nil, err
+	}
+
+	loc := time.UTC
+	if r.Options.Timezone != nil {
+		loc = r.Options.Timezone.Location
+	}
+
+	now := time.Now().In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	watermark, err := s.Watermark(ctx, r.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	from := time.Date(r.Start.Year(), r.Start.Month(), r.Start.Day(), 0, 0, 0, 0, time.UTC)
+	if !watermark.IsZero() {
+		if trailing := watermark.AddDate(0, 0, -r.trailingDays()); trailing.After(from) {
+			from = trailing
+		}
+	}
+
+	result := &Result{From: from, To: today}
+
+	for start := from; !start.After(today); {
+		end := time.Date(start.Year(), start.Month()+1, 0, 0, 0, 0, 0, time.UTC)
+		if end.After(today) {
+			end = today
+		}
+
+		n, err := s.syncRange(ctx, r, start, end)
+		if err != nil {
+			return nil, fmt.Errorf("syncing %s from %s to %s: %w", r.Name, start.Format("2006-01-02"), end.Format("2006-01-02"), err)
+		}
+
+		result.Rows += n
+		start = end.AddDate(0, 0, 1)
+	}
+
+	return result, nil
+}
+
+func (s *Syncer) syncRange(ctx context.Context, r Report, from, to time.Time) (int, error) {
+	opts := r.Options
+	opts.OutputCsvFields = r.columns()
+	opts.Filter.DateFrom = exoclick.CustomDate{Time: from}
+	opts.Filter.DateTo = exoclick.CustomDate{Time: to}
+
+	rows, err := s.fetch(ctx, opts)
+	if err != nil {
+		return 0, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	deleteQuery := fmt.Sprintf(
+		"DELETE FROM %s WHERE %s >= %s AND %s <= %s",
+		quote(r.Table), quote(string(exoclick.Date)), s.dialect.Placeholder(1), quote(string(exoclick.Date)), s.dialect.Placeholder(2),
+	)
+
+	if _, err := tx.ExecContext(ctx, deleteQuery, s.dialect.DateValue(from), s.dialect.DateValue(to)); err != nil {
+		return 0, err
+	}
+
+	stmt, err := tx.PrepareContext(ctx, s.upsertQuery(r))
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+
+	columns := r.columns()
+
+	for _, row := range rows {
+		if row.Date == nil {
+			return 0, errors.New("statistics row has no date")
+		}
+
+		args := make([]any, len(columns))
+		for i, field := range columns {
+			args[i] = s.value(row, field)
+
+			if args[i] == nil && slices.Contains(r.Options.GroupBy, field) {
+				args[i], _ = keyDefault(field)
+			}
+		}
+
+		if _, err := stmt.ExecContext(ctx, args...); err != nil {
+			return 0, err
+		}
+	}
+
+	watermarkQuery := fmt.Sprintf(
+		"INSERT INTO %s (report, synced_to) VALUES (%s, %s) ON CONFLICT (report) DO UPDATE SET synced_to = excluded.synced_to",
+		quote(WatermarkTable), s.dialect.Placeholder(1), s.dialect.Placeholder(2),
+	)
+
+	if _, err := tx.ExecContext(ctx, watermarkQuery, r.Name, to.Format("2006-01-02")); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return len(rows), nil
+}
+
+func (s *Syncer) fetch(ctx context.Context, opts exoclick.StatisticsOptions) ([]*exoclick.Statistic, error) {
+	var rows []*exoclick.Statistic
+
+	for offset := 0; ; offset += statisticsPageLimit {
+		opts.ListOptions = exoclick.ListOptions{Limit: statisticsPageLimit, Offset: offset}
+
+		page, _, err := s.fetcher.GetStatisticsCSV(ctx, &opts)
+		if err != nil {
+			return nil, err
+		}
+
+		rows = append(rows, page...)
+
+		if len(page) < statisticsPageLimit {
+			return rows, nil
+		}
+	}
+}
+
+func (s *Syncer) upsertQuery(r Report) string {
+	columns := r.columns()
+
+	names := make([]string, len(columns))
+	placeholders := make([]string, len(columns))
+	var updates []string
+
+	for i, field := range columns {
+		names[i] = quote(string(field))
+		placeholders[i] = s.dialect.Placeholder(i + 1)
+
+		if !slices.Contains(r.Options.GroupBy, field) {
+			updates = append(updates, names[i]+" = excluded."+names[i])
+		}
+	}
+
+	conflict := "DO NOTHING"
+	if len(updates) > 0 {
+		conflict = "DO UPDATE SET " + strings.Join(updates, ", ")
+	}
+
+	return fmt.Sprintf(
+		"INSERT INTO %s (%s) VALUES (%s) ON CONFLICT (%s) %s",
+		quote(r.Table), strings.Join(names, ", "), strings.Join(placeholders, ", "),
+		strings.Join(names[:len(r.Options.GroupBy)], ", "), conflict,
+	)
+}
+
+func (s *Syncer) value(row *exoclick.Statistic, field exoclick.StatisticsField) any {
+	intValue := func(v *int) any {
+		if v == nil {
+			return nil
+		}
+		return int64(*v)
+	}
+
+	strValue := func(v *string) any {
+		if v == nil {
+			return nil
+		}
+		return *v
+	}
+
+	switch field {
+	case exoclick.Date:
+		if row.Date == nil {
+			return nil
+		}
+		return s.dialect.DateValue(*row.Date)
+	case exoclick.Hour:
+		return intValue(row.Hour)
+	case exoclick.CampaignID:
+		return intValue(row.CampaignID)
+	case exoclick.VariationID:
+		return intValue(row.VariationID)
+	case exoclick.SiteID:
+		return intValue(row.SiteID)
+	case exoclick.SiteName:
+		return strValue(row.SiteName)
+	case exoclick.ZoneID:
+		return intValue(row.ZoneID)
+	case exoclick.ZoneName:
+		return strValue(row.ZoneName)
+	case exoclick.CategoryID:
+		return intValue(row.CategoryID)
+	case exoclick.Cost:
+		return row.DecimalCost()
+	default:
+		v, _ := row.Metric(field)
+		return int64(v)
+	}
+}
+
+func keyDefault(field exoclick.StatisticsField) (any, string) {
+	switch field {
+	case exoclick.SiteName, exoclick.ZoneName:
+		return "", "''"
+	default:
+		return int64(0), "0"
+	}
+}